@@ -0,0 +1,60 @@
+// Command buildcorpus counts a directory of job descriptions into the
+// baseline keyword corpus, services/keyword_corpus.json. Each .txt file is
+// one document, tokenised as the analyser tokenises keywords.
+//
+// Usage:
+//
+//	go generate ./services
+//
+// or directly:
+//
+//	go run ./cmd/buildcorpus -o services/keyword_corpus.json corpus/jobs
+package main
+
+import (
+	"ats-analyzer/services"
+	"flag"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+)
+
+func main() {
+	output := flag.String("o", "keyword_corpus.json", "file to write the corpus to")
+	version := flag.String("version", "", "version recorded in the corpus")
+	minDocFreq := flag.Int("min-df", 2, "leave out terms fewer documents than this use")
+	flag.Parse()
+	if flag.NArg() == 0 {
+		logrus.Fatal("Usage: buildcorpus [-o file] [-version v] [-min-df n] dir...")
+	}
+
+	var files []string
+	for _, dir := range flag.Args() {
+		matches, err := filepath.Glob(filepath.Join(dir, "*.txt"))
+		if err != nil {
+			logrus.Fatalf("Failed to list %s: %v", dir, err)
+		}
+		files = append(files, matches...)
+	}
+	sort.Strings(files)
+
+	var documents []string
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			logrus.Fatalf("Failed to read %s: %v", file, err)
+		}
+		documents = append(documents, string(data))
+	}
+
+	data, err := services.BuildKeywordCorpus(*version, documents, *minDocFreq)
+	if err != nil {
+		logrus.Fatalf("Failed to build keyword corpus: %v", err)
+	}
+	if err := os.WriteFile(*output, data, 0644); err != nil {
+		logrus.Fatalf("Failed to write %s: %v", *output, err)
+	}
+	logrus.Infof("Counted %d documents into %s", len(documents), *output)
+}
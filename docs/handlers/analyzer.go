@@ -3,10 +3,11 @@ package handlers
 import (
         "ats-analyzer/models"
         "ats-analyzer/services"
-        "ats-analyzer/utils"
+        "context"
         "fmt"
         "net/http"
         "path/filepath"
+        "strconv"
         "strings"
 
         "github.com/gin-gonic/gin"
@@ -37,42 +38,105 @@ func AnalyzeResume(c *gin.Context) {
         file := files[0]
         
         // Validate file
-        if !utils.IsValidResumeFile(file.Filename) {
-                c.JSON(http.StatusBadRequest, gin.H{
-                        "error": "Invalid file format. Only PDF and DOCX files are supported",
-                })
+        extractors := services.DefaultExtractors()
+        if err := extractors.CheckFile(file.Filename, file.Size); err != nil {
+                rejectUpload(c, err)
                 return
         }
 
         // Get job description (optional)
         jobDescText := c.PostForm("job_description")
 
-        // Save uploaded file temporarily
-        filename := fmt.Sprintf("uploads/%d_%s", 
-                utils.GenerateTimestamp(), 
-                filepath.Base(file.Filename))
-        
-        if err := c.SaveUploadedFile(file, filename); err != nil {
-                logrus.Errorf("Failed to save uploaded file: %v", err)
+        // Select scoring profile from form field or query parameter
+        scorer := services.NewScorer()
+        if err := scorer.UseProfile(scoringProfileName(c)); err != nil {
+                c.JSON(http.StatusBadRequest, gin.H{
+                        "error": err.Error(),
+                })
+                return
+        }
+        scorer.ExperienceOptions = experienceOptionsFromForm(c)
+
+        // Read the upload into memory; it only touches disk when a spool
+        // directory is configured, and is released when the analysis ends
+        upload, err := openUpload(file, filepath.Base(file.Filename))
+        if err != nil {
+                logrus.Errorf("Failed to read uploaded file: %v", err)
+                rejectUpload(c, err)
+                return
+        }
+
+        // The content must match the name before anything parses it
+        if err := extractors.CheckContent(upload.Name, upload, upload.Size); err != nil {
+                upload.Close()
+                rejectUpload(c, err)
+                return
+        }
+
+        run := func(ctx context.Context, onStage services.StageFunc) (interface{}, error) {
+                onStage.Emit(services.StageUploaded, gin.H{
+                        "file_name": file.Filename,
+                        "size":      upload.Size,
+                })
+                return analyzeUpload(ctx, scorer, upload, jobDescText, onStage)
+        }
+
+        // Large files can be analysed in the background and polled for. The
+        // job manager releases the upload, even if the job is cancelled
+        // before it starts.
+        if isAsync(c) {
+                submitJob(c, "analyze", run, func() { upload.Close() })
+                return
+        }
+        defer upload.Close()
+
+        // Or streamed to the client stage by stage
+        if isStream(c) {
+                streamAnalysis(c, run)
+                return
+        }
+
+        result, err := run(c.Request.Context(), nil)
+        if err != nil {
                 c.JSON(http.StatusInternalServerError, gin.H{
-                        "error": "Failed to save uploaded file",
+                        "error": err.Error(),
                 })
                 return
         }
 
+        switch result := result.(type) {
+        case *models.AnalysisResult:
+                logrus.Infof("Analysis completed with score: %.2f", result.Score)
+        case *models.UnreadableResult:
+                logrus.Infof("Resume is not ATS-readable: %s", result.Reason)
+        }
+        
+        c.JSON(http.StatusOK, gin.H{
+                "success": true,
+                "data": result,
+        })
+}
+
+// analyzeUpload parses an uploaded resume and scores it against the job
+// description, or on its own when no job description is given. Each
+// completed stage is reported to onStage. A resume with no text an ATS
+// could read gives a *models.UnreadableResult rather than a score.
+func analyzeUpload(ctx context.Context, scorer *services.Scorer, upload *services.Upload, jobDescText string, onStage services.StageFunc) (interface{}, error) {
         // Parse resume
         parser := services.NewParser()
-        resume, err := parser.ParseResume(filename)
+        resume, err := parser.ParseResumeFrom(upload.Name, upload, upload.Size, onStage)
+        if unreadable := services.UnreadableResult(upload.Name, err); unreadable != nil {
+                return unreadable, nil
+        }
         if err != nil {
                 logrus.Errorf("Failed to parse resume: %v", err)
-                c.JSON(http.StatusInternalServerError, gin.H{
-                        "error": "Failed to parse resume: " + err.Error(),
-                })
-                return
+                return nil, fmt.Errorf("Failed to parse resume: %v", err)
+        }
+        if err := ctx.Err(); err != nil {
+                return nil, err
         }
 
         // Analyze and score
-        scorer := services.NewScorer()
         var analysis *models.AnalysisResult
         
         if jobDescText != "" && strings.TrimSpace(jobDescText) != "" {
@@ -80,24 +144,42 @@ func AnalyzeResume(c *gin.Context) {
                 jobDesc, err := parser.ParseJobDescription(jobDescText)
                 if err != nil {
                         logrus.Errorf("Failed to parse job description: %v", err)
-                        c.JSON(http.StatusInternalServerError, gin.H{
-                                "error": "Failed to parse job description: " + err.Error(),
-                        })
-                        return
+                        return nil, fmt.Errorf("Failed to parse job description: %v", err)
                 }
+                onStage.Emit(services.StageJDParsed, jobDesc)
                 analysis = scorer.AnalyzeResume(resume, jobDesc)
         } else {
                 // Analyze resume without job description
                 analysis = scorer.AnalyzeResumeStandalone(resume)
         }
+        onStage.Emit(services.StageScored, analysis)
 
-        // Clean up temporary file
-        utils.CleanupFile(filename)
+        return analysis, nil
+}
 
-        logrus.Infof("Analysis completed with score: %.2f", analysis.Score)
-        
-        c.JSON(http.StatusOK, gin.H{
-                "success": true,
-                "data": analysis,
-        })
+// experienceOptionsFromForm reads the optional experience calculation settings
+// from the request, falling back to the defaults
+func experienceOptionsFromForm(c *gin.Context) models.ExperienceOptions {
+        opts := models.DefaultExperienceOptions()
+
+        if v, err := strconv.ParseBool(c.PostForm("exclude_internships")); err == nil {
+                opts.ExcludeInternships = v
+        }
+        if v, err := strconv.ParseBool(c.PostForm("exclude_part_time")); err == nil {
+                opts.ExcludePartTime = v
+        }
+        if v, err := strconv.Atoi(c.PostForm("gap_threshold_months")); err == nil && v >= 0 {
+                opts.GapThresholdMonths = v
+        }
+
+        return opts
+}
+
+// scoringProfileName returns the requested scoring profile, preferring the
+// form field over the query parameter
+func scoringProfileName(c *gin.Context) string {
+        if name := strings.TrimSpace(c.PostForm("profile")); name != "" {
+                return name
+        }
+        return strings.TrimSpace(c.Query("profile"))
 }
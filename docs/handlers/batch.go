@@ -0,0 +1,307 @@
+package handlers
+
+import (
+	"archive/zip"
+	"ats-analyzer/models"
+	"ats-analyzer/services"
+	"ats-analyzer/utils"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// maxBatchFiles limits the resumes accepted in one batch request
+	maxBatchFiles = 100
+	// maxZipEntrySize limits the uncompressed size of a resume inside a ZIP
+	maxZipEntrySize = 10 * 1024 * 1024
+	// maxZipTotalSize limits the uncompressed size of all the resumes
+	// unpacked from a request's ZIP archives
+	maxZipTotalSize = 100 * 1024 * 1024
+)
+
+// batchLimitError is a ZIP archive that would take the batch over its
+// resume count or unpacked size limit. The whole request is rejected.
+type batchLimitError struct {
+	message string
+}
+
+func (e *batchLimitError) Error() string {
+	return e.message
+}
+
+// BatchWorkersEnv names the environment variable that sets how many resumes
+// a batch request analyses concurrently
+const BatchWorkersEnv = "BATCH_WORKERS"
+
+// AnalyzeBatch ranks many resumes, uploaded individually or as a ZIP archive,
+// against a single job description
+func AnalyzeBatch(c *gin.Context) {
+	form, err := c.MultipartForm()
+	if err != nil {
+		logrus.Errorf("Failed to parse multipart form: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Failed to parse form data",
+		})
+		return
+	}
+
+	files := append(form.File["resumes"], form.File["resume"]...)
+	if len(files) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "At least one resume file or ZIP archive is required",
+		})
+		return
+	}
+
+	jobDescText := c.PostForm("job_description")
+	if err := utils.ValidateJobDescription(jobDescText); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "A job description is required for batch analysis: " + err.Error(),
+		})
+		return
+	}
+
+	scorer := services.NewScorer()
+	if err := scorer.UseProfile(scoringProfileName(c)); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	scorer.ExperienceOptions = experienceOptionsFromForm(c)
+
+	// Read uploads and unpack archives, remembering every upload so it is
+	// released. A queued job takes over the uploads and releases them when
+	// it ends.
+	var items []services.BatchItem
+	var uploads []*services.Upload
+	cleanup := func() {
+		for _, upload := range uploads {
+			upload.Close()
+		}
+	}
+	queued := false
+	defer func() {
+		if !queued {
+			cleanup()
+		}
+	}()
+
+	zipBudget := int64(maxZipTotalSize)
+	for _, file := range files {
+		name := filepath.Base(file.Filename)
+
+		if strings.EqualFold(filepath.Ext(name), ".zip") {
+			entries, err := readZipResumes(file, name, maxBatchFiles-len(items), &zipBudget)
+			var limitErr *batchLimitError
+			if errors.As(err, &limitErr) {
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error": limitErr.Error(),
+				})
+				return
+			}
+			if err != nil {
+				items = append(items, services.BatchItem{FileName: name, Err: err})
+				continue
+			}
+			for _, entry := range entries {
+				if entry.Upload != nil {
+					uploads = append(uploads, entry.Upload)
+				}
+			}
+			items = append(items, entries...)
+			continue
+		}
+
+		item := readBatchFile(file, name)
+		if item.Upload != nil {
+			uploads = append(uploads, item.Upload)
+		}
+		items = append(items, item)
+	}
+
+	if len(items) > maxBatchFiles {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("Too many resumes: %d (max: %d)", len(items), maxBatchFiles),
+		})
+		return
+	}
+
+	parser := services.NewParser()
+	jobDesc, err := parser.ParseJobDescription(jobDescText)
+	if err != nil {
+		logrus.Errorf("Failed to parse job description: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to parse job description: " + err.Error(),
+		})
+		return
+	}
+
+	includeDetails, _ := strconv.ParseBool(c.PostForm("include_details"))
+	analyzer := services.NewBatchAnalyzer(scorer, batchWorkers())
+	run := func(ctx context.Context, onStage services.StageFunc) (interface{}, error) {
+		// Ranking progress starts after the job description, so it
+		// does not count towards it
+		if onStage != nil {
+			onStage(models.ProgressEvent{Stage: services.StageJDParsed, Data: jobDesc})
+		}
+		return analyzer.Rank(ctx, items, jobDesc, includeDetails, onStage)
+	}
+
+	if isAsync(c) {
+		queued = true
+		submitJob(c, "batch", run, cleanup)
+		return
+	}
+
+	if isStream(c) {
+		streamAnalysis(c, run)
+		return
+	}
+
+	data, err := run(c.Request.Context(), nil)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	result := data.(*models.BatchResult)
+
+	logrus.Infof("Batch analysis completed: %d ranked, %d failed", result.Succeeded, result.Failed)
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// readBatchFile validates and reads a single uploaded resume
+func readBatchFile(file *multipart.FileHeader, name string) services.BatchItem {
+	extractors := services.DefaultExtractors()
+	if err := extractors.CheckFile(name, file.Size); err != nil {
+		return services.BatchItem{FileName: name, Err: err}
+	}
+
+	upload, err := openUpload(file, name)
+	if err != nil {
+		logrus.Errorf("Failed to read uploaded file: %v", err)
+		return services.BatchItem{FileName: name, Err: fmt.Errorf("failed to read uploaded file")}
+	}
+	if err := extractors.CheckContent(upload.Name, upload, upload.Size); err != nil {
+		upload.Close()
+		return services.BatchItem{FileName: name, Err: err}
+	}
+
+	return services.BatchItem{FileName: name, Upload: upload}
+}
+
+// readZipResumes unpacks the resumes in an uploaded ZIP archive, returning
+// one item per resume entry. The archive itself is released once read.
+// Reading stops with a batchLimitError, releasing what was read, once the
+// archive holds more than maxFiles resumes or its entries use up budget,
+// the uncompressed bytes the request has left.
+func readZipResumes(file *multipart.FileHeader, name string, maxFiles int, budget *int64) (items []services.BatchItem, err error) {
+	archive, err := openUpload(file, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ZIP archive: %v", err)
+	}
+	defer archive.Close()
+
+	reader, err := zip.NewReader(archive, archive.Size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ZIP archive: %v", err)
+	}
+
+	defer func() {
+		if err == nil {
+			return
+		}
+		for _, item := range items {
+			if item.Upload != nil {
+				item.Upload.Close()
+			}
+		}
+	}()
+
+	extractors := services.DefaultExtractors()
+	for _, entry := range reader.File {
+		name := filepath.Base(entry.Name)
+		// Skip directories and metadata added by archivers
+		if entry.FileInfo().IsDir() || strings.HasPrefix(entry.Name, "__MACOSX/") || strings.HasPrefix(name, ".") {
+			continue
+		}
+		if len(items) >= maxFiles {
+			return items, &batchLimitError{fmt.Sprintf("Too many resumes: %s takes the batch over the limit of %d", name, maxBatchFiles)}
+		}
+
+		if err := extractors.CheckFile(name, int64(entry.UncompressedSize64)); err != nil {
+			items = append(items, services.BatchItem{FileName: entry.Name, Err: err})
+			continue
+		}
+
+		upload, err := readZipEntry(entry, name, budget)
+		var limitErr *batchLimitError
+		if errors.As(err, &limitErr) {
+			return items, err
+		}
+		if err != nil {
+			items = append(items, services.BatchItem{FileName: entry.Name, Err: err})
+			continue
+		}
+		if err := extractors.CheckContent(upload.Name, upload, upload.Size); err != nil {
+			upload.Close()
+			items = append(items, services.BatchItem{FileName: entry.Name, Err: err})
+			continue
+		}
+		items = append(items, services.BatchItem{FileName: entry.Name, Upload: upload})
+	}
+
+	if len(items) == 0 {
+		return nil, fmt.Errorf("ZIP archive contains no resumes")
+	}
+
+	return items, nil
+}
+
+// readZipEntry reads a single archive entry, refusing entries whose content
+// exceeds the declared size limit. What it reads is taken from budget, and
+// an entry that overruns the budget is a batchLimitError.
+func readZipEntry(entry *zip.File, name string, budget *int64) (*services.Upload, error) {
+	src, err := entry.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ZIP entry: %v", err)
+	}
+	defer src.Close()
+
+	limited := &io.LimitedReader{R: src, N: *budget + 1}
+	upload, err := services.ReadUpload(name, limited, maxZipEntrySize)
+	*budget -= (*budget + 1) - limited.N
+	if *budget < 0 {
+		if upload != nil {
+			upload.Close()
+		}
+		return nil, &batchLimitError{fmt.Sprintf("ZIP archives unpack to more than %d bytes", maxZipTotalSize)}
+	}
+	return upload, err
+}
+
+// batchWorkers returns the configured worker count, or zero for the default
+func batchWorkers() int {
+	workers, err := strconv.Atoi(os.Getenv(BatchWorkersEnv))
+	if err != nil {
+		return 0
+	}
+	return workers
+}
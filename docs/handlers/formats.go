@@ -0,0 +1,18 @@
+package handlers
+
+import (
+	"ats-analyzer/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListFormats returns the resume formats uploads may use
+func ListFormats(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"formats": services.DefaultExtractors().Formats(),
+		},
+	})
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"ats-analyzer/services"
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// GetJob returns the status, progress and, once finished, the result of an
+// asynchronous job
+func GetJob(c *gin.Context) {
+	job, err := services.DefaultJobManager().Get(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    job,
+	})
+}
+
+// CancelJob stops a queued or running job
+func CancelJob(c *gin.Context) {
+	job, err := services.DefaultJobManager().Cancel(c.Param("id"))
+	switch {
+	case errors.Is(err, services.ErrJobNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": err.Error(),
+		})
+		return
+	case errors.Is(err, services.ErrJobFinished):
+		c.JSON(http.StatusConflict, gin.H{
+			"error": err.Error(),
+			"data":  job,
+		})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    job,
+	})
+}
+
+// isAsync reports whether the request asked to run in the background, from
+// the async form field or query parameter
+func isAsync(c *gin.Context) bool {
+	value := c.PostForm("async")
+	if value == "" {
+		value = c.Query("async")
+	}
+	async, _ := strconv.ParseBool(value)
+	return async
+}
+
+// submitJob queues run and responds with the job to poll. cleanup releases
+// what run uses and is always called exactly once: when the job ends, when
+// it is cancelled before it starts, or straight away if it could not be
+// queued.
+func submitJob(c *gin.Context, jobType string, run analysisFunc, cleanup func()) {
+	job, err := services.DefaultJobManager().Submit(jobType, func(ctx context.Context, progress services.ProgressFunc) (interface{}, error) {
+		return run(ctx, services.ProgressStages(progress))
+	}, cleanup)
+	if err != nil {
+		cleanup()
+		logrus.Errorf("Failed to submit %s job: %v", jobType, err)
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	logrus.Infof("Queued %s job %s", jobType, job.ID)
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"success":    true,
+		"data":       job,
+		"status_url": "/api/v1/jobs/" + job.ID,
+	})
+}
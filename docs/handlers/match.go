@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"ats-analyzer/models"
+	"ats-analyzer/services"
+	"ats-analyzer/utils"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// maxMatchRoles limits the job descriptions one resume is matched against
+const maxMatchRoles = 200
+
+// MatchJobs scores one resume against stored and submitted job descriptions
+// and returns the roles that fit best
+func MatchJobs(c *gin.Context) {
+	form, err := c.MultipartForm()
+	if err != nil {
+		logrus.Errorf("Failed to parse multipart form: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Failed to parse form data",
+		})
+		return
+	}
+
+	files := form.File["resume"]
+	if len(files) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Resume file is required",
+		})
+		return
+	}
+
+	file := files[0]
+	extractors := services.DefaultExtractors()
+	if err := extractors.CheckFile(file.Filename, file.Size); err != nil {
+		rejectUpload(c, err)
+		return
+	}
+
+	listings, err := matchListings(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	scorer := services.NewScorer()
+	if err := scorer.UseProfile(scoringProfileName(c)); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	scorer.ExperienceOptions = experienceOptionsFromForm(c)
+
+	upload, err := openUpload(file, filepath.Base(file.Filename))
+	if err != nil {
+		logrus.Errorf("Failed to read uploaded file: %v", err)
+		rejectUpload(c, err)
+		return
+	}
+	defer upload.Close()
+
+	if err := extractors.CheckContent(upload.Name, upload, upload.Size); err != nil {
+		rejectUpload(c, err)
+		return
+	}
+
+	// The resume is parsed once and scored against every role
+	parser := services.NewParser()
+	resume, err := parser.ParseResumeFrom(upload.Name, upload, upload.Size, nil)
+	if unreadable := services.UnreadableResult(upload.Name, err); unreadable != nil {
+		c.JSON(http.StatusOK, gin.H{
+			"success": true,
+			"data":    unreadable,
+		})
+		return
+	}
+	if err != nil {
+		logrus.Errorf("Failed to parse resume: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to parse resume: " + err.Error(),
+		})
+		return
+	}
+
+	includeDetails, _ := strconv.ParseBool(c.PostForm("include_details"))
+	result := services.NewReverseMatcher(scorer).Match(resume, listings, includeDetails)
+
+	logrus.Infof("Matched resume against %d roles", result.TotalRoles)
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// ListRoles returns the stored job descriptions resumes can be matched against
+func ListRoles(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    services.DefaultJobLibrary().Jobs,
+	})
+}
+
+// matchListings collects the roles to match against: stored jobs selected
+// with job_ids and descriptions submitted with job_descriptions. Without
+// either, every stored job is used.
+func matchListings(c *gin.Context) ([]models.JobListing, error) {
+	library := services.DefaultJobLibrary()
+	var listings []models.JobListing
+
+	for _, value := range c.PostFormArray("job_ids") {
+		for _, id := range strings.Split(value, ",") {
+			id = strings.TrimSpace(id)
+			if id == "" {
+				continue
+			}
+			job, err := library.Get(id)
+			if err != nil {
+				return nil, err
+			}
+			listings = append(listings, job)
+		}
+	}
+
+	for i, text := range c.PostFormArray("job_descriptions") {
+		if err := utils.ValidateJobDescription(text); err != nil {
+			return nil, fmt.Errorf("job description %d: %v", i+1, err)
+		}
+		listings = append(listings, models.JobListing{
+			ID:          fmt.Sprintf("submitted-%d", i+1),
+			Description: text,
+		})
+	}
+
+	if len(listings) == 0 {
+		listings = library.Jobs
+	}
+	if len(listings) == 0 {
+		return nil, fmt.Errorf("no job descriptions to match against, submit job_descriptions or configure a job library")
+	}
+	if len(listings) > maxMatchRoles {
+		return nil, fmt.Errorf("too many job descriptions: %d (max: %d)", len(listings), maxMatchRoles)
+	}
+
+	return listings, nil
+}
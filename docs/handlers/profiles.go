@@ -0,0 +1,21 @@
+package handlers
+
+import (
+	"ats-analyzer/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListProfiles returns the scoring profiles a request can select
+func ListProfiles(c *gin.Context) {
+	profiles := services.DefaultScoringProfiles()
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"default":  profiles.Default,
+			"profiles": profiles.Profiles,
+		},
+	})
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"ats-analyzer/models"
+	"ats-analyzer/services"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// Events closing an analysis stream
+const (
+	streamComplete = "complete"
+	streamError    = "error"
+)
+
+// analysisFunc runs an analysis, reporting each completed stage to onStage
+type analysisFunc func(ctx context.Context, onStage services.StageFunc) (interface{}, error)
+
+// isStream reports whether the request asked for Server-Sent Events, either
+// through a /stream route or the stream form field or query parameter
+func isStream(c *gin.Context) bool {
+	if strings.HasSuffix(c.FullPath(), "/stream") {
+		return true
+	}
+
+	value := c.PostForm("stream")
+	if value == "" {
+		value = c.Query("stream")
+	}
+	stream, _ := strconv.ParseBool(value)
+	return stream
+}
+
+// streamAnalysis runs the analysis and streams every stage to the client as
+// a Server-Sent Event named after the stage, ending with a complete event
+// holding the result or an error event. The analysis stops if the client
+// disconnects.
+func streamAnalysis(c *gin.Context, run analysisFunc) {
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+
+	events := make(chan models.ProgressEvent)
+	send := func(event models.ProgressEvent) {
+		select {
+		case events <- event:
+		case <-ctx.Done():
+		}
+	}
+
+	go func() {
+		defer close(events)
+
+		result, err := runRecovered(ctx, run, send)
+		if err != nil {
+			logrus.Errorf("Streamed analysis failed: %v", err)
+			send(models.ProgressEvent{
+				Stage: streamError,
+				Data:  gin.H{"error": err.Error()},
+			})
+			return
+		}
+		send(models.ProgressEvent{
+			Stage:    streamComplete,
+			Progress: 100,
+			Data:     result,
+		})
+	}()
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	c.Stream(func(w io.Writer) bool {
+		event, ok := <-events
+		if !ok {
+			return false
+		}
+		c.SSEvent(event.Stage, event)
+		return true
+	})
+
+	// Let the analysis finish or notice the cancellation before the
+	// handler returns
+	cancel()
+	for range events {
+	}
+}
+
+// runRecovered calls run, turning a panic into an error so it is sent as an
+// error event instead of taking down the process from the stream goroutine
+func runRecovered(ctx context.Context, run analysisFunc, send services.StageFunc) (result interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			logrus.Errorf("Streamed analysis panicked: %v", r)
+			err = fmt.Errorf("internal error: %v", r)
+		}
+	}()
+
+	return run(ctx, send)
+}
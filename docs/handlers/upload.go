@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"ats-analyzer/services"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// openUpload reads an uploaded file into memory, or into the spool
+// directory when one is configured. The caller closes the upload.
+func openUpload(file *multipart.FileHeader, name string) (*services.Upload, error) {
+	src, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	return services.ReadUpload(name, src, file.Size)
+}
+
+// rejectUpload responds to an upload that failed validation with its
+// rejection code
+func rejectUpload(c *gin.Context, err error) {
+	code := services.ErrorCode(err)
+	if code == "" {
+		logrus.Errorf("Failed to validate uploaded file: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to read uploaded file",
+		})
+		return
+	}
+
+	status := http.StatusBadRequest
+	if code == services.CodeFileTooLarge {
+		status = http.StatusRequestEntityTooLarge
+	}
+	c.JSON(status, gin.H{
+		"error": err.Error(),
+		"code":  code,
+	})
+}
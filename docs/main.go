@@ -2,6 +2,7 @@ package main
 
 import (
 	"ats-analyzer/handlers"
+	"ats-analyzer/services"
 	"net/http"
 	"os"
 
@@ -26,13 +27,23 @@ func main() {
 	api := r.Group("/api/v1")
 	{
 		api.POST("/analyze", handlers.AnalyzeResume)
+		api.POST("/analyze/stream", handlers.AnalyzeResume)
+		api.POST("/analyze/batch", handlers.AnalyzeBatch)
+		api.POST("/analyze/batch/stream", handlers.AnalyzeBatch)
+		api.POST("/match", handlers.MatchJobs)
+		api.GET("/roles", handlers.ListRoles)
+		api.GET("/jobs/:id", handlers.GetJob)
+		api.DELETE("/jobs/:id", handlers.CancelJob)
+		api.GET("/profiles", handlers.ListProfiles)
+		api.GET("/formats", handlers.ListFormats)
 		api.GET("/health", func(c *gin.Context) {
 			c.JSON(http.StatusOK, gin.H{"status": "healthy"})
 		})
 	}
 
-	// Create uploads directory if it doesn't exist
-	os.MkdirAll("uploads", 0755)
+	// Uploads are held in memory unless a spool directory is configured;
+	// clear out any a previous run left behind
+	services.CleanSpoolDir()
 
 	// Start server
 	port := os.Getenv("PORT")
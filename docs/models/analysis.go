@@ -1,18 +1,38 @@
 package models
 
+// Statuses of an analysis result
+const (
+	// StatusScored is a resume that was read and scored
+	StatusScored = "scored"
+	// StatusNotATSReadable is a resume with no text an ATS could read
+	StatusNotATSReadable = "not_ats_readable"
+)
+
 // AnalysisResult represents the complete analysis result
 type AnalysisResult struct {
+	Status           string             `json:"status"`
 	Score            float64            `json:"score"`
 	SkillMatch       SkillMatchResult   `json:"skill_match"`
+	TitleMatch       TitleMatchResult   `json:"title_match"`
 	ExperienceMatch  ExperienceResult   `json:"experience_match"`
 	EducationMatch   EducationResult    `json:"education_match"`
 	FormatScore      FormatResult       `json:"format_score"`
+	KeywordMatch     KeywordMatchResult `json:"keyword_match"`
 	MissingKeywords  []string           `json:"missing_keywords"`
 	Suggestions      []string           `json:"suggestions"`
 	MatchedKeywords  []string           `json:"matched_keywords"`
 	ScoreBreakdown   ScoreBreakdown     `json:"score_breakdown"`
 }
 
+// UnreadableResult is returned instead of an analysis for a resume an ATS
+// could not read, such as a scanned PDF, explaining why
+type UnreadableResult struct {
+	Status   string `json:"status"`
+	Reason   string `json:"reason"`
+	Message  string `json:"message"`
+	FileName string `json:"file_name,omitempty"`
+}
+
 // SkillMatchResult contains skill matching details
 type SkillMatchResult struct {
 	Percentage      float64  `json:"percentage"`
@@ -20,6 +40,35 @@ type SkillMatchResult struct {
 	MissingSkills   []string `json:"missing_skills"`
 	TotalRequired   int      `json:"total_required"`
 	TotalMatched    int      `json:"total_matched"`
+	MatchedRequired  []string `json:"matched_required"`
+	MissingRequired  []string `json:"missing_required"`
+	MatchedPreferred []string `json:"matched_preferred"`
+	MissingPreferred []string `json:"missing_preferred"`
+	RequiredPercentage  float64 `json:"required_percentage"`
+	PreferredPercentage float64 `json:"preferred_percentage"`
+	MatchedByCategory map[string][]string `json:"matched_by_category"`
+	MissingByCategory map[string][]string `json:"missing_by_category"`
+	TaxonomyVersion string   `json:"taxonomy_version"`
+}
+
+// KeywordMatchResult is how much of a job description's skills and
+// keywords a resume covers, weighted by their importance. Matched and
+// Missing are ordered most important first.
+type KeywordMatchResult struct {
+	Percentage float64         `json:"percentage"`
+	Matched    []KeywordWeight `json:"matched"`
+	Missing    []KeywordWeight `json:"missing"`
+}
+
+// TitleMatchResult contains job title matching details
+type TitleMatchResult struct {
+	Score              float64 `json:"score"`
+	JobTitle           string  `json:"job_title"`
+	NormalizedJobTitle string  `json:"normalized_job_title"`
+	JobFamily          string  `json:"job_family,omitempty"`
+	BestMatch          string  `json:"best_match"`
+	SameFamily         bool    `json:"same_family"`
+	SeniorityMatch     bool    `json:"seniority_match"`
 }
 
 // ExperienceResult contains experience matching details
@@ -28,6 +77,21 @@ type ExperienceResult struct {
 	YearsRequired   int     `json:"years_required"`
 	YearsCandidate  float64 `json:"years_candidate"`
 	MeetsRequirement bool   `json:"meets_requirement"`
+	RoleBreakdown    []RoleYears        `json:"role_breakdown"`
+	SkillYears       map[string]float64 `json:"skill_years"`
+	Gaps             []EmploymentGap    `json:"gaps"`
+	Requirements     []RequirementResult `json:"requirements"`
+	Options          ExperienceOptions  `json:"options"`
+}
+
+// RequirementResult is the outcome of one per-skill experience requirement
+type RequirementResult struct {
+	Skill          string  `json:"skill,omitempty"`
+	Domain         string  `json:"domain,omitempty"`
+	MinYears       int     `json:"min_years"`
+	Required       bool    `json:"required"`
+	CandidateYears float64 `json:"candidate_years"`
+	Met            bool    `json:"met"`
 }
 
 // EducationResult contains education matching details
@@ -39,18 +103,40 @@ type EducationResult struct {
 
 // FormatResult contains ATS formatting analysis
 type FormatResult struct {
-	Score  float64  `json:"score"`
-	Issues []string `json:"issues"`
+	Score  float64       `json:"score"`
+	Issues []FormatIssue `json:"issues"`
 	IsATSFriendly bool `json:"is_ats_friendly"`
 }
 
+// Severities of a formatting issue
+const (
+	// SeverityError is an issue likely to lose content in an ATS
+	SeverityError = "error"
+	// SeverityWarning is an issue some ATSs handle poorly
+	SeverityWarning = "warning"
+	// SeverityInfo is worth knowing but rarely harmful
+	SeverityInfo = "info"
+)
+
+// FormatIssue is one formatting problem found in a resume. Location says
+// where, such as "page 2" or "header", when the issue is not document-wide.
+type FormatIssue struct {
+	Code     string `json:"code"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+	Location string `json:"location,omitempty"`
+}
+
 // ScoreBreakdown shows how the final score was calculated
 type ScoreBreakdown struct {
+	Profile          string  `json:"profile"`
 	SkillWeight      float64 `json:"skill_weight"`
+	TitleWeight      float64 `json:"title_weight"`
 	ExperienceWeight float64 `json:"experience_weight"`
 	EducationWeight  float64 `json:"education_weight"`
 	FormatWeight     float64 `json:"format_weight"`
 	SkillScore       float64 `json:"skill_score"`
+	TitleScore       float64 `json:"title_score"`
 	ExperienceScore  float64 `json:"experience_score"`
 	EducationScore   float64 `json:"education_score"`
 	FormatScore      float64 `json:"format_score"`
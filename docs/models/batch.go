@@ -0,0 +1,32 @@
+package models
+
+// BatchResult ranks many resumes against a single job description
+type BatchResult struct {
+	JobTitle   string            `json:"job_title"`
+	Total      int               `json:"total"`
+	Succeeded  int               `json:"succeeded"`
+	Failed     int               `json:"failed"`
+	Candidates []CandidateResult `json:"candidates"`
+	Failures   []BatchFailure    `json:"failures"`
+}
+
+// CandidateResult is one ranked resume in a batch
+type CandidateResult struct {
+	Rank             int             `json:"rank"`
+	FileName         string          `json:"file_name"`
+	Name             string          `json:"name"`
+	Email            string          `json:"email"`
+	Score            float64         `json:"score"`
+	SkillMatch       float64         `json:"skill_match"`
+	YearsExperience  float64         `json:"years_experience"`
+	TopMissingSkills []string        `json:"top_missing_skills"`
+	ScoreBreakdown   ScoreBreakdown  `json:"score_breakdown"`
+	Analysis         *AnalysisResult `json:"analysis,omitempty"`
+}
+
+// BatchFailure reports a resume that could not be analysed
+type BatchFailure struct {
+	FileName string `json:"file_name"`
+	Error    string `json:"error"`
+	Code     string `json:"code,omitempty"`
+}
@@ -0,0 +1,51 @@
+package models
+
+// Kinds of text block an extractor produces
+const (
+	BlockParagraph = "paragraph"
+	BlockHeading   = "heading"
+	BlockListItem  = "list_item"
+	BlockTableRow  = "table_row"
+)
+
+// Regions of a document a text block can come from, for formats that keep
+// text outside the main flow
+const (
+	OriginBody     = "body"
+	OriginTable    = "table"
+	OriginHeader   = "header"
+	OriginFooter   = "footer"
+	OriginTextBox  = "text_box"
+	OriginFootnote = "footnote"
+	OriginEndnote  = "endnote"
+)
+
+// TextBlock is one unit of text extracted from a resume document. Level is
+// the heading level or list nesting depth, starting at 1. Origin is the
+// region the block came from, when the extractor tells regions apart.
+type TextBlock struct {
+	Kind   string       `json:"kind"`
+	Text   string       `json:"text"`
+	Level  int          `json:"level,omitempty"`
+	Origin string       `json:"origin,omitempty"`
+	Layout *BlockLayout `json:"layout,omitempty"`
+}
+
+// BlockLayout is where a block sits on the page, for formats with a fixed
+// layout such as PDF. Coordinates are in points from the bottom-left corner
+// of the page, with Y at the baseline of the block's first line.
+type BlockLayout struct {
+	Page     int     `json:"page"`
+	Column   int     `json:"column"`
+	X        float64 `json:"x"`
+	Y        float64 `json:"y"`
+	Width    float64 `json:"width"`
+	FontSize float64 `json:"font_size"`
+}
+
+// DocumentFormat describes a resume file format the analyzer can read
+type DocumentFormat struct {
+	Name       string   `json:"name"`
+	Extensions []string `json:"extensions"`
+	MIMETypes  []string `json:"mime_types"`
+}
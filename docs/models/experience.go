@@ -0,0 +1,183 @@
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+// Employment types detected for experience entries
+const (
+	EmploymentFullTime   = "full-time"
+	EmploymentPartTime   = "part-time"
+	EmploymentInternship = "internship"
+	EmploymentContract   = "contract"
+)
+
+const hoursPerYear = 24 * 365.25
+
+// ExperienceOptions controls how total experience is calculated
+type ExperienceOptions struct {
+	ExcludeInternships bool `json:"exclude_internships"`
+	ExcludePartTime    bool `json:"exclude_part_time"`
+	GapThresholdMonths int  `json:"gap_threshold_months"`
+}
+
+// DefaultExperienceOptions counts every role and reports gaps over six months
+func DefaultExperienceOptions() ExperienceOptions {
+	return ExperienceOptions{
+		GapThresholdMonths: 6,
+	}
+}
+
+// RoleYears is the duration of a single experience entry
+type RoleYears struct {
+	Company        string  `json:"company"`
+	Position       string  `json:"position"`
+	EmploymentType string  `json:"employment_type"`
+	Years          float64 `json:"years"`
+	Excluded       bool    `json:"excluded"`
+}
+
+// EmploymentGap is a period without any counted role
+type EmploymentGap struct {
+	Start  time.Time `json:"start"`
+	End    time.Time `json:"end"`
+	Months float64   `json:"months"`
+}
+
+// interval is a half-open period of employment: end is the day after the
+// last day worked, so back-to-back roles meet without a gap
+type interval struct {
+	start time.Time
+	end   time.Time
+}
+
+// CalculateExperienceYearsWith calculates total years of experience, merging
+// overlapping roles so concurrent or duplicated entries are counted once
+func (r *Resume) CalculateExperienceYearsWith(opts ExperienceOptions) float64 {
+	return MergedExperienceYears(r.countedExperience(opts))
+}
+
+// RoleBreakdown returns the duration of every experience entry and whether
+// it was excluded from the total
+func (r *Resume) RoleBreakdown(opts ExperienceOptions) []RoleYears {
+	now := time.Now()
+	var roles []RoleYears
+
+	for _, exp := range r.Experience {
+		var years float64
+		if iv, ok := exp.interval(now); ok {
+			years = iv.end.Sub(iv.start).Hours() / hoursPerYear
+		}
+		roles = append(roles, RoleYears{
+			Company:        exp.Company,
+			Position:       exp.Position,
+			EmploymentType: exp.EmploymentType,
+			Years:          years,
+			Excluded:       !opts.Counts(exp),
+		})
+	}
+
+	return roles
+}
+
+// EmploymentGaps returns gaps between counted roles longer than the
+// configured threshold
+func (r *Resume) EmploymentGaps(opts ExperienceOptions) []EmploymentGap {
+	merged := mergeIntervals(r.countedExperience(opts))
+	var gaps []EmploymentGap
+
+	for i := 1; i < len(merged); i++ {
+		start := merged[i-1].end
+		end := merged[i].start
+		months := end.Sub(start).Hours() / hoursPerYear * 12
+		if months > float64(opts.GapThresholdMonths) {
+			gaps = append(gaps, EmploymentGap{
+				Start:  start,
+				End:    end,
+				Months: months,
+			})
+		}
+	}
+
+	return gaps
+}
+
+// MergedExperienceYears returns the years covered by the given entries with
+// overlapping periods counted once
+func MergedExperienceYears(experience []Experience) float64 {
+	var totalYears float64
+	for _, iv := range mergeIntervals(experience) {
+		totalYears += iv.end.Sub(iv.start).Hours() / hoursPerYear
+	}
+	return totalYears
+}
+
+// countedExperience returns the entries included by the options
+func (r *Resume) countedExperience(opts ExperienceOptions) []Experience {
+	var counted []Experience
+	for _, exp := range r.Experience {
+		if opts.Counts(exp) {
+			counted = append(counted, exp)
+		}
+	}
+	return counted
+}
+
+// Counts reports whether an entry is included in total experience
+func (opts ExperienceOptions) Counts(exp Experience) bool {
+	if opts.ExcludeInternships && exp.EmploymentType == EmploymentInternship {
+		return false
+	}
+	if opts.ExcludePartTime && exp.EmploymentType == EmploymentPartTime {
+		return false
+	}
+	return true
+}
+
+// interval returns the period an entry covers, ending now for current roles.
+// EndDate is the inclusive last day, so the interval ends a day later.
+func (exp Experience) interval(now time.Time) (interval, bool) {
+	if exp.StartDate.IsZero() {
+		return interval{}, false
+	}
+
+	end := now
+	if exp.EndDate != nil {
+		end = exp.EndDate.AddDate(0, 0, 1)
+	}
+	if !end.After(exp.StartDate) {
+		return interval{}, false
+	}
+
+	return interval{start: exp.StartDate, end: end}, true
+}
+
+// mergeIntervals sorts entry periods and merges any that overlap
+func mergeIntervals(experience []Experience) []interval {
+	now := time.Now()
+	var intervals []interval
+	for _, exp := range experience {
+		if iv, ok := exp.interval(now); ok {
+			intervals = append(intervals, iv)
+		}
+	}
+
+	sort.Slice(intervals, func(i, j int) bool {
+		return intervals[i].start.Before(intervals[j].start)
+	})
+
+	var merged []interval
+	for _, iv := range intervals {
+		last := len(merged) - 1
+		if last >= 0 && !iv.start.After(merged[last].end) {
+			if iv.end.After(merged[last].end) {
+				merged[last].end = iv.end
+			}
+			continue
+		}
+		merged = append(merged, iv)
+	}
+
+	return merged
+}
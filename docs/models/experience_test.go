@@ -0,0 +1,243 @@
+package models
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func day(year int, month time.Month, d int) *time.Time {
+	t := time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+	return &t
+}
+
+func TestExperienceEndDateIsInclusive(t *testing.T) {
+	resume := &Resume{Experience: []Experience{
+		// January to June 2018, then July 2018 to December 2019
+		{StartDate: *day(2018, time.January, 1), EndDate: day(2018, time.June, 30)},
+		{StartDate: *day(2018, time.July, 1), EndDate: day(2019, time.December, 31)},
+		// The whole of 2021 after a year's gap
+		{StartDate: *day(2021, time.January, 1), EndDate: day(2021, time.December, 31)},
+	}}
+	opts := DefaultExperienceOptions()
+
+	if years := resume.CalculateExperienceYearsWith(opts); math.Abs(years-3) > 0.01 {
+		t.Errorf("total = %.4f years, want 3", years)
+	}
+
+	roles := resume.RoleBreakdown(opts)
+	if math.Abs(roles[2].Years-1) > 0.01 {
+		t.Errorf("2021 role = %.4f years, want 1", roles[2].Years)
+	}
+
+	gaps := resume.EmploymentGaps(opts)
+	if len(gaps) != 1 {
+		t.Fatalf("gaps = %+v, want only the 2020 gap", gaps)
+	}
+	if !gaps[0].Start.Equal(*day(2020, time.January, 1)) || !gaps[0].End.Equal(*day(2021, time.January, 1)) {
+		t.Errorf("gap = %s to %s, want 2020-01-01 to 2021-01-01", gaps[0].Start.Format("2006-01-02"), gaps[0].End.Format("2006-01-02"))
+	}
+}
+
+// role is an experience entry from start to end inclusive
+func role(employmentType string, start, end *time.Time) Experience {
+	return Experience{EmploymentType: employmentType, StartDate: *start, EndDate: end}
+}
+
+func TestMergeIntervals(t *testing.T) {
+	tests := []struct {
+		name       string
+		experience []Experience
+		want       []string
+	}{
+		{
+			name: "overlapping",
+			experience: []Experience{
+				role("", day(2018, time.January, 1), day(2019, time.June, 30)),
+				role("", day(2019, time.January, 1), day(2020, time.December, 31)),
+			},
+			want: []string{"2018-01-01/2021-01-01"},
+		},
+		{
+			name: "nested",
+			experience: []Experience{
+				role("", day(2015, time.January, 1), day(2020, time.December, 31)),
+				role("", day(2017, time.March, 1), day(2017, time.August, 31)),
+			},
+			want: []string{"2015-01-01/2021-01-01"},
+		},
+		{
+			name: "touching",
+			experience: []Experience{
+				role("", day(2018, time.January, 1), day(2018, time.June, 30)),
+				role("", day(2018, time.July, 1), day(2018, time.December, 31)),
+			},
+			want: []string{"2018-01-01/2019-01-01"},
+		},
+		{
+			name: "a day apart",
+			experience: []Experience{
+				role("", day(2018, time.January, 1), day(2018, time.June, 30)),
+				role("", day(2018, time.July, 2), day(2018, time.December, 31)),
+			},
+			want: []string{"2018-01-01/2018-07-01", "2018-07-02/2019-01-01"},
+		},
+		{
+			name: "duplicated",
+			experience: []Experience{
+				role("", day(2019, time.May, 1), day(2020, time.April, 30)),
+				role("", day(2019, time.May, 1), day(2020, time.April, 30)),
+			},
+			want: []string{"2019-05-01/2020-05-01"},
+		},
+		{
+			name: "out of order",
+			experience: []Experience{
+				role("", day(2021, time.January, 1), day(2021, time.December, 31)),
+				role("", day(2016, time.January, 1), day(2016, time.December, 31)),
+			},
+			want: []string{"2016-01-01/2017-01-01", "2021-01-01/2022-01-01"},
+		},
+		{
+			name: "undated and inverted entries",
+			experience: []Experience{
+				{EndDate: day(2019, time.December, 31)},
+				role("", day(2020, time.June, 1), day(2020, time.January, 31)),
+				role("", day(2018, time.January, 1), day(2018, time.December, 31)),
+			},
+			want: []string{"2018-01-01/2019-01-01"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, iv := range mergeIntervals(tt.experience) {
+				got = append(got, iv.start.Format("2006-01-02")+"/"+iv.end.Format("2006-01-02"))
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("merged = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("merged = %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestEmploymentGapThreshold(t *testing.T) {
+	// Six months is 182.6 days
+	tests := []struct {
+		name    string
+		gapDays int
+		gaps    int
+	}{
+		{name: "just under", gapDays: 180, gaps: 0},
+		{name: "just over", gapDays: 185, gaps: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			restart := day(2020, time.January, 1).AddDate(0, 0, tt.gapDays)
+			resume := &Resume{Experience: []Experience{
+				role("", day(2018, time.January, 1), day(2019, time.December, 31)),
+				role("", &restart, day(2022, time.December, 31)),
+			}}
+			gaps := resume.EmploymentGaps(DefaultExperienceOptions())
+			if len(gaps) != tt.gaps {
+				t.Fatalf("gaps = %+v, want %d", gaps, tt.gaps)
+			}
+			if tt.gaps == 1 && math.Abs(gaps[0].Months-float64(tt.gapDays)/365.25*12) > 0.001 {
+				t.Errorf("gap = %.3f months, want %d days", gaps[0].Months, tt.gapDays)
+			}
+		})
+	}
+}
+
+func TestExperienceOptions(t *testing.T) {
+	// A year of each, back to back from 2016, but the contract starts after
+	// a year off
+	resume := &Resume{Experience: []Experience{
+		role(EmploymentFullTime, day(2016, time.January, 1), day(2016, time.December, 31)),
+		role(EmploymentInternship, day(2017, time.January, 1), day(2017, time.December, 31)),
+		role(EmploymentPartTime, day(2018, time.January, 1), day(2018, time.December, 31)),
+		role(EmploymentContract, day(2020, time.January, 1), day(2020, time.December, 31)),
+	}}
+
+	tests := []struct {
+		name     string
+		opts     ExperienceOptions
+		years    float64
+		excluded []bool
+		gaps     int
+	}{
+		{
+			name:     "everything",
+			opts:     DefaultExperienceOptions(),
+			years:    4,
+			excluded: []bool{false, false, false, false},
+			gaps:     1,
+		},
+		{
+			name:     "without internships",
+			opts:     ExperienceOptions{ExcludeInternships: true, GapThresholdMonths: 6},
+			years:    3,
+			excluded: []bool{false, true, false, false},
+			gaps:     2,
+		},
+		{
+			name:     "without part-time",
+			opts:     ExperienceOptions{ExcludePartTime: true, GapThresholdMonths: 6},
+			years:    3,
+			excluded: []bool{false, false, true, false},
+			gaps:     1,
+		},
+		{
+			name:     "without either",
+			opts:     ExperienceOptions{ExcludeInternships: true, ExcludePartTime: true, GapThresholdMonths: 6},
+			years:    2,
+			excluded: []bool{false, true, true, false},
+			gaps:     1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if years := resume.CalculateExperienceYearsWith(tt.opts); math.Abs(years-tt.years) > 0.01 {
+				t.Errorf("total = %.4f years, want %.0f", years, tt.years)
+			}
+
+			// The breakdown lists every role, counted or not
+			roles := resume.RoleBreakdown(tt.opts)
+			if len(roles) != len(resume.Experience) {
+				t.Fatalf("breakdown has %d roles, want %d", len(roles), len(resume.Experience))
+			}
+			var listed, counted float64
+			for i, r := range roles {
+				listed += r.Years
+				if r.Excluded != tt.excluded[i] {
+					t.Errorf("%s role excluded = %v, want %v", r.EmploymentType, r.Excluded, tt.excluded[i])
+				}
+				if r.Excluded == tt.opts.Counts(resume.Experience[i]) {
+					t.Errorf("%s role excluded = %v but Counts = %v", r.EmploymentType, r.Excluded, !r.Excluded)
+				}
+				if !r.Excluded {
+					counted += r.Years
+				}
+			}
+			if math.Abs(listed-4) > 0.01 {
+				t.Errorf("breakdown lists %.4f years, want 4", listed)
+			}
+			if math.Abs(counted-tt.years) > 0.01 {
+				t.Errorf("breakdown counts %.4f years, want %.0f", counted, tt.years)
+			}
+
+			if gaps := resume.EmploymentGaps(tt.opts); len(gaps) != tt.gaps {
+				t.Errorf("gaps = %+v, want %d", gaps, tt.gaps)
+			}
+		})
+	}
+}
@@ -11,5 +11,25 @@ type JobDescription struct {
 	Location          string   `json:"location"`
 	Description       string   `json:"description"`
 	Keywords          []string `json:"keywords"`
+	KeywordWeights    []KeywordWeight `json:"keyword_weights"`
 	RawText           string   `json:"raw_text"`
+	ExperienceRequirements []ExperienceRequirement `json:"experience_requirements"`
+}
+
+// ExperienceRequirement is a years-of-experience requirement from a job
+// description. Skill names a taxonomy skill, Domain a free-text area such as
+// "cloud"; both are empty for a general requirement.
+type ExperienceRequirement struct {
+	Skill    string `json:"skill,omitempty"`
+	Domain   string `json:"domain,omitempty"`
+	MinYears int    `json:"min_years"`
+	Required bool   `json:"required"`
+	Text     string `json:"text"`
+}
+
+// KeywordWeight is a keyword and its importance to a document, from 0 to 1
+// relative to the document's most important keyword
+type KeywordWeight struct {
+	Term   string  `json:"term"`
+	Weight float64 `json:"weight"`
 }
@@ -0,0 +1,43 @@
+package models
+
+// JobListing is a stored or submitted job description a resume can be
+// matched against
+type JobListing struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+// ReverseMatchResult ranks job descriptions for a single resume
+type ReverseMatchResult struct {
+	CandidateName string        `json:"candidate_name"`
+	TotalRoles    int           `json:"total_roles"`
+	Roles         []RoleMatch   `json:"roles"`
+	Failures      []RoleFailure `json:"failures"`
+}
+
+// RoleMatch is one job description scored against the resume, with the
+// gaps the candidate would need to close
+type RoleMatch struct {
+	Rank              int                 `json:"rank"`
+	JobID             string              `json:"job_id"`
+	JobTitle          string              `json:"job_title"`
+	Score             float64             `json:"score"`
+	SkillMatch        float64             `json:"skill_match"`
+	TitleScore        float64             `json:"title_score"`
+	MatchedSkills     []string            `json:"matched_skills"`
+	MissingRequired   []string            `json:"missing_required"`
+	MissingPreferred  []string            `json:"missing_preferred"`
+	YearsRequired     int                 `json:"years_required"`
+	YearsCandidate    float64             `json:"years_candidate"`
+	MeetsExperience   bool                `json:"meets_experience"`
+	UnmetRequirements []RequirementResult `json:"unmet_requirements"`
+	ScoreBreakdown    ScoreBreakdown      `json:"score_breakdown"`
+	Analysis          *AnalysisResult     `json:"analysis,omitempty"`
+}
+
+// RoleFailure reports a job description that could not be parsed
+type RoleFailure struct {
+	JobID string `json:"job_id"`
+	Error string `json:"error"`
+}
@@ -0,0 +1,10 @@
+package models
+
+// ProgressEvent reports a completed analysis stage with any partial result
+// available at that point
+type ProgressEvent struct {
+	Stage    string      `json:"stage"`
+	FileName string      `json:"file_name,omitempty"`
+	Progress float64     `json:"progress"`
+	Data     interface{} `json:"data,omitempty"`
+}
@@ -11,15 +11,66 @@ type Resume struct {
 	Projects     []Project    `json:"projects"`
 	Certifications []string   `json:"certifications"`
 	RawText      string       `json:"raw_text"`
-	FormatIssues []string     `json:"format_issues"`
+	FormatIssues []FormatIssue `json:"format_issues"`
+	Sections     map[string]string `json:"sections"`
 }
 
-// PersonalInfo contains basic personal information
+// Canonical section names produced by resume segmentation
+const (
+	SectionHeader         = "header"
+	SectionSummary        = "summary"
+	SectionExperience     = "experience"
+	SectionEducation      = "education"
+	SectionSkills         = "skills"
+	SectionProjects       = "projects"
+	SectionCertifications = "certifications"
+	SectionAwards         = "awards"
+	SectionPublications   = "publications"
+	SectionVolunteering   = "volunteering"
+)
+
+// PersonalInfo contains basic personal information. Phone and Address are
+// as written on the resume; PhoneE164 is the number in international form,
+// when its country could be worked out, and Location the address's parts.
 type PersonalInfo struct {
-	Name    string `json:"name"`
-	Email   string `json:"email"`
-	Phone   string `json:"phone"`
-	Address string `json:"address"`
+	Name         string        `json:"name"`
+	Email        string        `json:"email"`
+	Phone        string        `json:"phone"`
+	PhoneE164    string        `json:"phone_e164,omitempty"`
+	PhoneCountry string        `json:"phone_country,omitempty"`
+	Address      string        `json:"address"`
+	Location     Location      `json:"location"`
+	Links        []ProfileLink `json:"links"`
+}
+
+// Location is where a candidate is based. Any part may be empty.
+type Location struct {
+	City    string `json:"city,omitempty"`
+	Region  string `json:"region,omitempty"`
+	Country string `json:"country,omitempty"`
+}
+
+// Kinds of profile link
+const (
+	LinkLinkedIn      = "linkedin"
+	LinkGitHub        = "github"
+	LinkGitLab        = "gitlab"
+	LinkStackOverflow = "stackoverflow"
+	LinkBitbucket     = "bitbucket"
+	LinkTwitter       = "twitter"
+	LinkKaggle        = "kaggle"
+	LinkMedium        = "medium"
+	LinkBehance       = "behance"
+	LinkDribbble      = "dribbble"
+	LinkWebsite       = "website"
+)
+
+// ProfileLink is a link to the candidate's profile or personal site.
+// Username is the profile name for sites that have one.
+type ProfileLink struct {
+	Type     string `json:"type"`
+	URL      string `json:"url"`
+	Username string `json:"username,omitempty"`
 }
 
 // Education represents educational background
@@ -35,9 +86,12 @@ type Experience struct {
 	Company     string    `json:"company"`
 	Position    string    `json:"position"`
 	StartDate   time.Time `json:"start_date"`
+	// EndDate is the last day of the role, inclusive; nil for current roles
 	EndDate     *time.Time `json:"end_date,omitempty"`
 	Description string    `json:"description"`
 	IsCurrent   bool      `json:"is_current"`
+	DatePrecision string  `json:"date_precision"`
+	EmploymentType string `json:"employment_type"`
 }
 
 // Project represents a project
@@ -47,21 +101,8 @@ type Project struct {
 	Technologies []string `json:"technologies"`
 }
 
-// CalculateExperienceYears calculates total years of experience
+// CalculateExperienceYears calculates total years of experience, counting
+// overlapping roles only once
 func (r *Resume) CalculateExperienceYears() float64 {
-	var totalYears float64
-	now := time.Now()
-
-	for _, exp := range r.Experience {
-		endDate := now
-		if exp.EndDate != nil {
-			endDate = *exp.EndDate
-		}
-		
-		duration := endDate.Sub(exp.StartDate)
-		years := duration.Hours() / (24 * 365.25)
-		totalYears += years
-	}
-
-	return totalYears
+	return r.CalculateExperienceYearsWith(DefaultExperienceOptions())
 }
@@ -0,0 +1,16 @@
+package models
+
+// SkillTaxonomyFile is the on-disk format of a skill taxonomy
+type SkillTaxonomyFile struct {
+	Version        string            `json:"version"`
+	AmbiguousTerms []string          `json:"ambiguous_terms"`
+	Skills         []SkillDefinition `json:"skills"`
+}
+
+// SkillDefinition describes one canonical skill in the taxonomy
+type SkillDefinition struct {
+	Name     string   `json:"name"`
+	Category string   `json:"category"`
+	Aliases  []string `json:"aliases,omitempty"`
+	Implies  []string `json:"implies,omitempty"`
+}
@@ -0,0 +1,205 @@
+package services
+
+import (
+	"ats-analyzer/models"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultBatchWorkers is the number of resumes analysed concurrently
+const DefaultBatchWorkers = 4
+
+// topMissingSkillCount limits the missing skills reported per candidate
+const topMissingSkillCount = 5
+
+// BatchItem is one resume file submitted for batch analysis. Items that
+// were rejected before parsing carry the error and are reported as failures.
+type BatchItem struct {
+	FileName string
+	Upload   *Upload
+	Err      error
+}
+
+// BatchAnalyzer ranks many resumes against one job description
+type BatchAnalyzer struct {
+	parser  *Parser
+	scorer  *Scorer
+	workers int
+}
+
+// NewBatchAnalyzer creates a batch analyzer using the given scorer and a
+// bounded pool of workers
+func NewBatchAnalyzer(scorer *Scorer, workers int) *BatchAnalyzer {
+	if workers <= 0 {
+		workers = DefaultBatchWorkers
+	}
+
+	return &BatchAnalyzer{
+		parser:  NewParser(),
+		scorer:  scorer,
+		workers: workers,
+	}
+}
+
+// batchOutcome is the result of analysing a single item
+type batchOutcome struct {
+	item      BatchItem
+	candidate models.CandidateResult
+	err       error
+}
+
+// Rank parses and scores every item concurrently and returns candidates
+// sorted by score. Items that fail to parse are reported individually.
+// Stage events for each file and the overall progress are sent to onStage;
+// once ctx is cancelled the remaining items are skipped and ctx's error is
+// returned.
+func (b *BatchAnalyzer) Rank(ctx context.Context, items []BatchItem, jobDesc *models.JobDescription, includeDetails bool, onStage StageFunc) (*models.BatchResult, error) {
+	jobs := make(chan BatchItem)
+	outcomes := make(chan batchOutcome, len(items))
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	done := 0
+	for i := 0; i < b.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for item := range jobs {
+				outcomes <- b.analyze(item, jobDesc, includeDetails, onStage)
+
+				mu.Lock()
+				done++
+				if onStage != nil {
+					onStage(models.ProgressEvent{
+						Stage:    StageBatchProgress,
+						Progress: float64(done) / float64(len(items)) * 100,
+						Data: map[string]int{
+							"completed": done,
+							"total":     len(items),
+						},
+					})
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+dispatch:
+	for _, item := range items {
+		select {
+		case jobs <- item:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	close(outcomes)
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	result := &models.BatchResult{
+		JobTitle:   jobDesc.Title,
+		Total:      len(items),
+		Candidates: []models.CandidateResult{},
+		Failures:   []models.BatchFailure{},
+	}
+
+	for outcome := range outcomes {
+		if outcome.err != nil {
+			result.Failures = append(result.Failures, models.BatchFailure{
+				FileName: outcome.item.FileName,
+				Error:    outcome.err.Error(),
+				Code:     ErrorCode(outcome.err),
+			})
+			continue
+		}
+		result.Candidates = append(result.Candidates, outcome.candidate)
+	}
+
+	sort.SliceStable(result.Candidates, func(i, j int) bool {
+		if result.Candidates[i].Score != result.Candidates[j].Score {
+			return result.Candidates[i].Score > result.Candidates[j].Score
+		}
+		return result.Candidates[i].FileName < result.Candidates[j].FileName
+	})
+	for i := range result.Candidates {
+		result.Candidates[i].Rank = i + 1
+	}
+
+	sort.Slice(result.Failures, func(i, j int) bool {
+		return result.Failures[i].FileName < result.Failures[j].FileName
+	})
+
+	result.Succeeded = len(result.Candidates)
+	result.Failed = len(result.Failures)
+
+	return result, nil
+}
+
+// analyze parses and scores a single resume, reporting its stages tagged
+// with the file name. A panic while reading the file, which would
+// otherwise take down the server from this worker goroutine, is reported
+// as the file's failure.
+func (b *BatchAnalyzer) analyze(item BatchItem, jobDesc *models.JobDescription, includeDetails bool, onStage StageFunc) (outcome batchOutcome) {
+	var fileStage StageFunc
+	if onStage != nil {
+		fileStage = func(event models.ProgressEvent) {
+			event.FileName = item.FileName
+			onStage(event)
+		}
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			logrus.Errorf("Batch analysis of %s panicked: %v", item.FileName, r)
+			err := fmt.Errorf("internal error while analysing the resume: %v", r)
+			fileStage.Emit(StageFailed, map[string]string{"error": err.Error(), "code": ErrorCode(err)})
+			outcome = batchOutcome{item: item, err: err}
+		}
+	}()
+
+	if item.Err != nil {
+		fileStage.Emit(StageFailed, map[string]string{"error": item.Err.Error(), "code": ErrorCode(item.Err)})
+		return batchOutcome{item: item, err: item.Err}
+	}
+	fileStage.Emit(StageUploaded, nil)
+
+	resume, err := b.parser.ParseResumeFrom(item.FileName, item.Upload, item.Upload.Size, fileStage)
+	if err != nil {
+		fileStage.Emit(StageFailed, map[string]string{"error": err.Error(), "code": ErrorCode(err)})
+		return batchOutcome{item: item, err: err}
+	}
+
+	analysis := b.scorer.AnalyzeResume(resume, jobDesc)
+	candidate := models.CandidateResult{
+		FileName:         item.FileName,
+		Name:             resume.PersonalInfo.Name,
+		Email:            resume.PersonalInfo.Email,
+		Score:            analysis.Score,
+		SkillMatch:       analysis.SkillMatch.Percentage,
+		YearsExperience:  analysis.ExperienceMatch.YearsCandidate,
+		TopMissingSkills: topSkills(analysis.SkillMatch.MissingSkills, topMissingSkillCount),
+		ScoreBreakdown:   analysis.ScoreBreakdown,
+	}
+	if includeDetails {
+		candidate.Analysis = analysis
+	}
+	fileStage.Emit(StageScored, candidate)
+
+	return batchOutcome{item: item, candidate: candidate}
+}
+
+// topSkills returns at most n skills
+func topSkills(skills []string, n int) []string {
+	if len(skills) > n {
+		skills = skills[:n]
+	}
+	return append([]string{}, skills...)
+}
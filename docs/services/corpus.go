@@ -0,0 +1,374 @@
+package services
+
+import (
+	"crypto/sha256"
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// baselineCorpusJSON holds the document frequencies shipped with the binary,
+// so generic hiring vocabulary is down-weighted before any documents have
+// been seen. It is counted from the job descriptions in corpus/jobs by
+// cmd/buildcorpus.
+//
+//go:generate go run ../cmd/buildcorpus -version 2.0.0 -o keyword_corpus.json ../corpus/jobs
+//go:embed keyword_corpus.json
+var baselineCorpusJSON []byte
+
+// KeywordCorpusEnv names the environment variable pointing at the file the
+// document frequencies of ingested job descriptions are kept in
+const KeywordCorpusEnv = "KEYWORD_CORPUS_PATH"
+
+// corpusSaveDelay batches the writes of a burst of ingested documents
+const corpusSaveDelay = 5 * time.Second
+
+// maxCorpusFingerprints bounds the fingerprints kept of ingested documents.
+// The oldest are forgotten first, so only a document resubmitted after this
+// many others is counted twice.
+const maxCorpusFingerprints = 10000
+
+// maxCorpusTerms bounds the terms counted from ingested documents. Beyond it
+// the rarest are pruned, which are mostly names, typos and other one-offs;
+// an unknown term weighs the same as one seen in a single document.
+const maxCorpusTerms = 50000
+
+// BM25 parameters: bm25K1 limits how much repeating a term adds to its
+// weight and bm25B how far long documents are discounted
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+var (
+	defaultCorpus     *KeywordCorpus
+	defaultCorpusOnce sync.Once
+)
+
+// corpusCounts are the document frequencies of a set of documents
+type corpusCounts struct {
+	Version    string         `json:"version,omitempty"`
+	Documents  int            `json:"documents"`
+	TotalTerms int            `json:"total_terms"`
+	DocFreq    map[string]int `json:"doc_freq"`
+}
+
+// corpusFile is the persisted form of the ingested documents. Fingerprints
+// identify the most recent documents counted, oldest first; no text is
+// stored.
+type corpusFile struct {
+	corpusCounts
+	Fingerprints []string `json:"fingerprints"`
+}
+
+// KeywordCorpus counts how many documents each term appears in, for
+// weighting keywords by how unusual they are. It combines the shipped
+// baseline with the job descriptions ingested since, which are saved to
+// KEYWORD_CORPUS_PATH when set. It is safe for concurrent use.
+type KeywordCorpus struct {
+	mu       sync.RWMutex
+	baseline corpusCounts
+	ingested corpusCounts
+	// fingerprints are those of recent documents, oldest first in
+	// fingerprintOrder
+	fingerprints     map[string]bool
+	fingerprintOrder []string
+	path             string
+	saveTimer        *time.Timer
+}
+
+// DefaultKeywordCorpus returns the process-wide corpus, loading documents
+// ingested by earlier runs from KEYWORD_CORPUS_PATH when set
+func DefaultKeywordCorpus() *KeywordCorpus {
+	defaultCorpusOnce.Do(func() {
+		corpus, err := NewKeywordCorpus(baselineCorpusJSON)
+		if err != nil {
+			panic(fmt.Sprintf("invalid built-in keyword corpus: %v", err))
+		}
+
+		if path := os.Getenv(KeywordCorpusEnv); path != "" {
+			if err := corpus.Load(path); err != nil {
+				logrus.Errorf("Failed to load keyword corpus from %s, starting afresh: %v", path, err)
+			}
+			corpus.path = path
+		}
+		defaultCorpus = corpus
+	})
+
+	return defaultCorpus
+}
+
+// NewKeywordCorpus creates a corpus from baseline document frequencies in
+// JSON
+func NewKeywordCorpus(baseline []byte) (*KeywordCorpus, error) {
+	var counts corpusCounts
+	if err := json.Unmarshal(baseline, &counts); err != nil {
+		return nil, fmt.Errorf("failed to decode keyword corpus: %v", err)
+	}
+	if err := counts.validate(); err != nil {
+		return nil, err
+	}
+
+	return &KeywordCorpus{
+		baseline:     counts,
+		ingested:     corpusCounts{DocFreq: make(map[string]int)},
+		fingerprints: make(map[string]bool),
+	}, nil
+}
+
+// Load replaces the ingested documents with those saved in filename. A
+// missing file is an empty corpus.
+func (c *KeywordCorpus) Load(filename string) error {
+	data, err := os.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var file corpusFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("failed to decode keyword corpus: %v", err)
+	}
+	if file.DocFreq == nil {
+		file.DocFreq = make(map[string]int)
+	}
+	if err := file.validate(); err != nil {
+		return err
+	}
+	file.prune(maxCorpusTerms)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ingested = file.corpusCounts
+	c.fingerprints = make(map[string]bool, len(file.Fingerprints))
+	c.fingerprintOrder = nil
+	for _, fingerprint := range file.Fingerprints {
+		c.remember(fingerprint)
+	}
+	return nil
+}
+
+// validate checks that the counts are consistent
+func (counts corpusCounts) validate() error {
+	if counts.Documents < 0 || counts.TotalTerms < 0 {
+		return fmt.Errorf("keyword corpus counts cannot be negative")
+	}
+	for term, df := range counts.DocFreq {
+		if df < 0 || df > counts.Documents {
+			return fmt.Errorf("keyword corpus frequency of %q is outside 0-%d", term, counts.Documents)
+		}
+	}
+	return nil
+}
+
+// add counts one document's tokens
+func (counts *corpusCounts) add(tokens []string) {
+	counts.Documents++
+	counts.TotalTerms += len(tokens)
+	seen := make(map[string]bool, len(tokens))
+	for _, token := range tokens {
+		if !seen[token] {
+			seen[token] = true
+			counts.DocFreq[token]++
+		}
+	}
+}
+
+// prune drops the terms fewest documents use until at most limit are left
+func (counts *corpusCounts) prune(limit int) {
+	for minDocFreq := 1; len(counts.DocFreq) > limit; minDocFreq++ {
+		for term, df := range counts.DocFreq {
+			if df <= minDocFreq {
+				delete(counts.DocFreq, term)
+			}
+		}
+	}
+}
+
+// Add counts a document's tokens. Documents are identified by their text,
+// so a job description parsed once per request is only counted once.
+func (c *KeywordCorpus) Add(text string, tokens []string) {
+	if len(tokens) == 0 {
+		return
+	}
+	fingerprint := documentFingerprint(text)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.fingerprints[fingerprint] {
+		return
+	}
+	c.remember(fingerprint)
+	c.ingested.add(tokens)
+	c.ingested.prune(maxCorpusTerms)
+
+	if c.path != "" && c.saveTimer == nil {
+		c.saveTimer = time.AfterFunc(corpusSaveDelay, c.flush)
+	}
+}
+
+// remember records a document's fingerprint, forgetting the oldest once
+// there are maxCorpusFingerprints. The caller must hold the lock.
+func (c *KeywordCorpus) remember(fingerprint string) {
+	if c.fingerprints[fingerprint] {
+		return
+	}
+	c.fingerprints[fingerprint] = true
+	c.fingerprintOrder = append(c.fingerprintOrder, fingerprint)
+
+	if excess := len(c.fingerprintOrder) - maxCorpusFingerprints; excess > 0 {
+		for _, old := range c.fingerprintOrder[:excess] {
+			delete(c.fingerprints, old)
+		}
+		// append reallocates once capacity runs out, releasing the
+		// forgotten entries
+		c.fingerprintOrder = c.fingerprintOrder[excess:]
+	}
+}
+
+// flush saves the corpus after a batch of additions
+func (c *KeywordCorpus) flush() {
+	c.mu.Lock()
+	c.saveTimer = nil
+	c.mu.Unlock()
+
+	if err := c.Save(); err != nil {
+		logrus.Errorf("Failed to save keyword corpus to %s: %v", c.path, err)
+	}
+}
+
+// Save writes the ingested documents to KEYWORD_CORPUS_PATH, replacing the
+// file atomically so a crash never leaves it half written
+func (c *KeywordCorpus) Save() error {
+	if c.path == "" {
+		return nil
+	}
+
+	c.mu.RLock()
+	file := corpusFile{corpusCounts: c.ingested, Fingerprints: c.fingerprintOrder}
+	data, err := json.Marshal(file)
+	c.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path)
+}
+
+// Contains reports whether a document has been counted in the corpus
+func (c *KeywordCorpus) Contains(text string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.fingerprints[documentFingerprint(text)]
+}
+
+// Documents returns how many documents the corpus has counted
+func (c *KeywordCorpus) Documents() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.baseline.Documents + c.ingested.Documents
+}
+
+// DocFreq returns how many documents in the corpus use a term
+func (c *KeywordCorpus) DocFreq(term string) int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.baseline.DocFreq[term] + c.ingested.DocFreq[term]
+}
+
+// IDF returns the BM25 inverse document frequency of a term, which is
+// highest for terms few documents use and never negative
+func (c *KeywordCorpus) IDF(term string) float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.idf(term)
+}
+
+func (c *KeywordCorpus) idf(term string) float64 {
+	n := float64(c.baseline.Documents + c.ingested.Documents)
+	df := float64(c.baseline.DocFreq[term] + c.ingested.DocFreq[term])
+	return math.Log(1 + (n-df+0.5)/(df+0.5))
+}
+
+// BM25 weighs each distinct token of a document by how often the document
+// uses it, with diminishing returns, and how rarely other documents do
+func (c *KeywordCorpus) BM25(tokens []string) map[string]float64 {
+	termFreq := make(map[string]int)
+	for _, token := range tokens {
+		termFreq[token]++
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	length := float64(len(tokens))
+	averageLength := length
+	if documents := c.baseline.Documents + c.ingested.Documents; documents > 0 {
+		averageLength = float64(c.baseline.TotalTerms+c.ingested.TotalTerms) / float64(documents)
+	}
+	norm := 1 - bm25B + bm25B*length/averageLength
+
+	scores := make(map[string]float64, len(termFreq))
+	for term, freq := range termFreq {
+		tf := float64(freq)
+		scores[term] = c.idf(term) * tf * (bm25K1 + 1) / (tf + bm25K1*norm)
+	}
+	return scores
+}
+
+// BuildKeywordCorpus counts the terms of documents as the baseline of a
+// keyword corpus, in JSON. Terms fewer than minDocFreq documents use are
+// left out.
+func BuildKeywordCorpus(version string, documents []string, minDocFreq int) ([]byte, error) {
+	nlp := NewNLPService()
+	counts := corpusCounts{Version: version, DocFreq: make(map[string]int)}
+	for _, document := range documents {
+		if tokens := nlp.keywordTokens(document); len(tokens) > 0 {
+			counts.add(tokens)
+		}
+	}
+	if counts.Documents == 0 {
+		return nil, fmt.Errorf("no documents to count")
+	}
+
+	for term, df := range counts.DocFreq {
+		if df < minDocFreq {
+			delete(counts.DocFreq, term)
+		}
+	}
+	data, err := json.MarshalIndent(counts, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// documentFingerprint identifies a document by its words, ignoring case and
+// spacing
+func documentFingerprint(text string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.Join(strings.Fields(text), " "))))
+	return hex.EncodeToString(sum[:16])
+}
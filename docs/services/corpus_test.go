@@ -0,0 +1,177 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testCorpusJSON = `{"version": "test", "documents": 2, "total_terms": 10, "doc_freq": {"team": 2, "kubernetes": 1}}`
+
+func TestKeywordCorpusCountsDocumentsOnce(t *testing.T) {
+	corpus, err := NewKeywordCorpus([]byte(testCorpusJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	before := corpus.IDF("terraform")
+	corpus.Add("Terraform  and AWS", []string{"terraform", "aws"})
+	corpus.Add("terraform and aws", []string{"terraform", "aws"})
+	if corpus.ingested.Documents != 1 || corpus.ingested.DocFreq["terraform"] != 1 {
+		t.Errorf("ingested = %+v, want the document counted once", corpus.ingested)
+	}
+	if after := corpus.IDF("terraform"); after >= before {
+		t.Errorf("IDF of a seen term rose from %.3f to %.3f", before, after)
+	}
+	if corpus.IDF("team") >= corpus.IDF("kubernetes") {
+		t.Error("a common term outweighs a rare one")
+	}
+}
+
+func TestKeywordCorpusBoundsFingerprints(t *testing.T) {
+	corpus, err := NewKeywordCorpus([]byte(testCorpusJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < maxCorpusFingerprints+50; i++ {
+		corpus.Add(fmt.Sprintf("document %d", i), []string{"document"})
+	}
+	if len(corpus.fingerprints) != maxCorpusFingerprints || len(corpus.fingerprintOrder) != maxCorpusFingerprints {
+		t.Fatalf("kept %d fingerprints (%d ordered), want %d", len(corpus.fingerprints), len(corpus.fingerprintOrder), maxCorpusFingerprints)
+	}
+	if corpus.fingerprints[documentFingerprint("document 0")] {
+		t.Error("the oldest fingerprint was kept")
+	}
+	if !corpus.fingerprints[documentFingerprint(fmt.Sprintf("document %d", maxCorpusFingerprints+49))] {
+		t.Error("the newest fingerprint was forgotten")
+	}
+
+	corpus.path = filepath.Join(t.TempDir(), "corpus.json")
+	if err := corpus.Save(); err != nil {
+		t.Fatal(err)
+	}
+	loaded, _ := NewKeywordCorpus([]byte(testCorpusJSON))
+	if err := loaded.Load(corpus.path); err != nil {
+		t.Fatal(err)
+	}
+	if loaded.ingested.Documents != maxCorpusFingerprints+50 || len(loaded.fingerprintOrder) != maxCorpusFingerprints {
+		t.Errorf("loaded %d documents and %d fingerprints", loaded.ingested.Documents, len(loaded.fingerprintOrder))
+	}
+	if loaded.fingerprintOrder[0] != corpus.fingerprintOrder[0] {
+		t.Error("fingerprints lost their order when saved")
+	}
+}
+
+func TestBaselineCorpusTermsSurviveTokenisation(t *testing.T) {
+	var baseline corpusCounts
+	if err := json.Unmarshal(baselineCorpusJSON, &baseline); err != nil {
+		t.Fatal(err)
+	}
+	if baseline.Documents == 0 || len(baseline.DocFreq) == 0 {
+		t.Fatal("the baseline corpus is empty")
+	}
+
+	nlp := NewNLPService()
+	for term := range baseline.DocFreq {
+		if tokens := nlp.keywordTokens(term); len(tokens) != 1 || tokens[0] != term {
+			t.Errorf("baseline term %q tokenises to %q", term, tokens)
+		}
+	}
+}
+
+func TestFillerNeverOutranksSkills(t *testing.T) {
+	text := `Backend Engineer
+We have been hiring quickly and are looking for an engineer who wants to join our new platform team.
+What you will do: build services in Python, run them on Kubernetes, manage infrastructure with
+Terraform and keep data in PostgreSQL. We also offer more than a competitive salary; tell us about
+the work you are most proud of.`
+	skills := []string{"python", "kubernetes", "terraform", "postgresql"}
+	filler := []string{"been", "hiring", "looking", "who", "wants", "join", "new", "what", "also",
+		"offer", "more", "competitive", "salary", "tell", "about", "work", "proud", "team"}
+
+	taxonomy := DefaultSkillTaxonomy()
+	weights := make(map[string]float64)
+	for _, keyword := range NewNLPService().RankKeywords(text, 100) {
+		weights[keyword.Term] = keyword.Weight
+	}
+	for _, skill := range skills {
+		if _, ok := taxonomy.Canonical(skill); !ok {
+			t.Fatalf("%q is not a taxonomy skill", skill)
+		}
+		if _, ok := weights[skill]; !ok {
+			t.Fatalf("skill %q was not ranked", skill)
+		}
+		for _, word := range filler {
+			if weight, ok := weights[word]; ok && weight >= weights[skill] {
+				t.Errorf("filler %q (%.3f) outranks skill %q (%.3f)", word, weight, skill, weights[skill])
+			}
+		}
+	}
+}
+
+func TestOnlyJobDescriptionsJoinTheCorpus(t *testing.T) {
+	corpus := DefaultKeywordCorpus()
+	parser := NewParser()
+
+	resume := `Jane Doe
+jane.doe@example.com | 12 Larkspur Lane, Springfield
+Experience
+Software Engineer, Initech, 2019 - 2023
+Built billing services in Go and PostgreSQL for a team of eight engineers.`
+	if _, err := parser.ParseResumeFrom("resume.txt", strings.NewReader(resume), int64(len(resume)), nil); err != nil {
+		t.Fatal(err)
+	}
+	corpus.mu.RLock()
+	added := corpus.fingerprints[documentFingerprint(resume)]
+	corpus.mu.RUnlock()
+	if added {
+		t.Error("a resume was added to the keyword corpus")
+	}
+
+	jd := "Backend Engineer at Initech. We need five years of Go and PostgreSQL experience."
+	if _, err := parser.ParseJobDescription(jd); err != nil {
+		t.Fatal(err)
+	}
+	corpus.mu.RLock()
+	added = corpus.fingerprints[documentFingerprint(jd)]
+	corpus.mu.RUnlock()
+	if !added {
+		t.Error("a job description was not added to the keyword corpus")
+	}
+}
+
+func TestKeywordCorpusPrunesRareTerms(t *testing.T) {
+	counts := corpusCounts{Documents: 3, DocFreq: map[string]int{
+		"jane": 1, "larkspur": 1, "golang": 2, "postgres": 2, "team": 3,
+	}}
+	counts.prune(3)
+	if len(counts.DocFreq) != 3 || counts.DocFreq["jane"] != 0 || counts.DocFreq["team"] != 3 {
+		t.Errorf("pruned to %v, want the terms used by one document dropped", counts.DocFreq)
+	}
+	counts.prune(1)
+	if len(counts.DocFreq) != 1 || counts.DocFreq["team"] != 3 {
+		t.Errorf("pruned to %v, want only the commonest term", counts.DocFreq)
+	}
+}
+
+func TestRankKeywordsLeavesOutUnseenAndGenericTerms(t *testing.T) {
+	corpus, err := NewKeywordCorpus([]byte(`{"documents": 20, "total_terms": 400, "doc_freq": {"billing": 2, "ledger": 1, "team": 12, "terraform": 15}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	nlp := &NLPService{stopWords: map[string]bool{}, corpus: corpus, taxonomy: DefaultSkillTaxonomy()}
+
+	text := "Billing team wants a ledger expert with Terraform and zyxwv"
+	corpus.Add(text, nlp.keywordTokens(text))
+	var terms []string
+	for _, keyword := range nlp.RankKeywords(text, 10) {
+		terms = append(terms, keyword.Term)
+	}
+	// terraform is a skill however common; team is generic, and the other
+	// words are used by no other document
+	if want := []string{"terraform", "ledger", "billing"}; strings.Join(terms, ",") != strings.Join(want, ",") {
+		t.Errorf("ranked %v, want %v", terms, want)
+	}
+}
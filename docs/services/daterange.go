@@ -0,0 +1,241 @@
+package services
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Date precision levels reported for parsed dates
+const (
+	PrecisionYear  = "year"
+	PrecisionMonth = "month"
+)
+
+// DateRange is a parsed employment or study period. End is nil when the
+// range is open ("Present"). Dates are inclusive, so an end of "Mar 2020"
+// is the last day of March and an end of "2021" the last day of 2021.
+type DateRange struct {
+	Start     time.Time
+	End       *time.Time
+	IsCurrent bool
+	Precision string
+	Raw       string
+}
+
+const (
+	monthPattern   = `(?:jan(?:uary)?|feb(?:ruary)?|mar(?:ch)?|apr(?:il)?|may|june?|july?|aug(?:ust)?|sept?(?:ember)?|oct(?:ober)?|nov(?:ember)?|dec(?:ember)?)\.?`
+	seasonPattern  = `(?:spring|summer|fall|autumn|winter)`
+	yearPattern    = `(?:19|20)\d{2}`
+	presentPattern = `(?:present|current(?:ly)?|now|today|ongoing|till date|to date|date)`
+	rangeSeparator = `\s*(?:-{1,2}|–|—|−|~|to|until|till|through|thru)\s*`
+)
+
+// dateTokenPattern matches a single date in any supported format. Longer
+// forms come first because Go regexp alternation is leftmost-first.
+var dateTokenPattern = `(?:` +
+	// 15 January 2020
+	`\d{1,2}(?:st|nd|rd|th)?\s+` + monthPattern + `,?\s+` + yearPattern +
+	// January 15, 2020 / Jan 2020 / Jan, 2020 / Jan '20
+	`|` + monthPattern + `(?:\s+\d{1,2}(?:st|nd|rd|th)?)?,?\s*(?:` + yearPattern + `|'\d{2})` +
+	// Spring 2018
+	`|` + seasonPattern + `,?\s+` + yearPattern +
+	// 2020-03 / 2020/03 / 2020.03
+	`|` + yearPattern + `[/.\-](?:0[1-9]|1[0-2])\b` +
+	// 01/2020 / 1-2020 / 03.2020
+	`|(?:0?[1-9]|1[0-2])\s*[/.\-]\s*` + yearPattern +
+	// 2020
+	`|` + yearPattern +
+	`)`
+
+var (
+	dateRangeRegex = regexp.MustCompile(`(?i)\b(` + dateTokenPattern + `)` + rangeSeparator +
+		`(` + dateTokenPattern + `|` + presentPattern + `)\b`)
+	sinceDateRegex = regexp.MustCompile(`(?i)\bsince\s+(` + dateTokenPattern + `)\b`)
+
+	dayMonthYearRegex = regexp.MustCompile(`(?i)^\d{1,2}(?:st|nd|rd|th)?\s+(` + monthPattern + `),?\s+(` + yearPattern + `)$`)
+	monthYearRegex    = regexp.MustCompile(`(?i)^(` + monthPattern + `)(?:\s+\d{1,2}(?:st|nd|rd|th)?)?,?\s*(` + yearPattern + `|'\d{2})$`)
+	seasonYearRegex   = regexp.MustCompile(`(?i)^(` + seasonPattern + `),?\s+(` + yearPattern + `)$`)
+	yearMonthRegex    = regexp.MustCompile(`^(` + yearPattern + `)[/.\-](0[1-9]|1[0-2])$`)
+	monthNumYearRegex = regexp.MustCompile(`^(0?[1-9]|1[0-2])\s*[/.\-]\s*(` + yearPattern + `)$`)
+	yearOnlyRegex     = regexp.MustCompile(`^(` + yearPattern + `)$`)
+	presentRegex      = regexp.MustCompile(`(?i)^` + presentPattern + `$`)
+)
+
+var seasonMonths = map[string]time.Month{
+	"spring": time.March,
+	"summer": time.June,
+	"fall":   time.September,
+	"autumn": time.September,
+	"winter": time.January,
+}
+
+// FindDateRanges returns every date range found in text, in order
+func FindDateRanges(text string) []DateRange {
+	var ranges []DateRange
+
+	for _, match := range dateRangeRegex.FindAllStringSubmatch(text, -1) {
+		if dr, ok := buildDateRange(match[0], match[1], match[2]); ok {
+			ranges = append(ranges, dr)
+		}
+	}
+
+	if len(ranges) == 0 {
+		// "Since 2020" is an open range
+		for _, match := range sinceDateRegex.FindAllStringSubmatch(text, -1) {
+			if dr, ok := buildDateRange(match[0], match[1], "present"); ok {
+				ranges = append(ranges, dr)
+			}
+		}
+	}
+
+	return ranges
+}
+
+// ParseDateRange parses the first date range in text
+func ParseDateRange(text string) (DateRange, bool) {
+	ranges := FindDateRanges(text)
+	if len(ranges) == 0 {
+		return DateRange{}, false
+	}
+	return ranges[0], true
+}
+
+// buildDateRange combines parsed start and end tokens into a range
+func buildDateRange(raw, startText, endText string) (DateRange, bool) {
+	start, startPrecision, ok := parseDateToken(startText)
+	if !ok {
+		return DateRange{}, false
+	}
+
+	dr := DateRange{
+		Start:     start,
+		Precision: startPrecision,
+		Raw:       strings.TrimSpace(raw),
+	}
+
+	if presentRegex.MatchString(strings.TrimSpace(endText)) {
+		dr.IsCurrent = true
+		return dr, true
+	}
+
+	end, endPrecision, ok := parseDateToken(endText)
+	if !ok {
+		return DateRange{}, false
+	}
+
+	// Inclusive end: move to the last day of the named month or year
+	if endPrecision == PrecisionYear {
+		end = end.AddDate(1, 0, -1)
+	} else {
+		end = end.AddDate(0, 1, -1)
+	}
+	if end.Before(start) {
+		return DateRange{}, false
+	}
+	dr.End = &end
+
+	if endPrecision == PrecisionYear {
+		dr.Precision = PrecisionYear
+	}
+
+	return dr, true
+}
+
+// parseDateToken parses a single date into the first day of the period it
+// names, along with its precision
+func parseDateToken(text string) (time.Time, string, bool) {
+	text = strings.TrimSpace(text)
+
+	if m := dayMonthYearRegex.FindStringSubmatch(text); m != nil {
+		return monthDate(m[1], m[2])
+	}
+	if m := monthYearRegex.FindStringSubmatch(text); m != nil {
+		return monthDate(m[1], m[2])
+	}
+	if m := seasonYearRegex.FindStringSubmatch(text); m != nil {
+		year, _ := strconv.Atoi(m[2])
+		return time.Date(year, seasonMonths[strings.ToLower(m[1])], 1, 0, 0, 0, 0, time.UTC), PrecisionMonth, true
+	}
+	if m := yearMonthRegex.FindStringSubmatch(text); m != nil {
+		return numericDate(m[2], m[1])
+	}
+	if m := monthNumYearRegex.FindStringSubmatch(text); m != nil {
+		return numericDate(m[1], m[2])
+	}
+	if m := yearOnlyRegex.FindStringSubmatch(text); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		return time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC), PrecisionYear, true
+	}
+
+	return time.Time{}, "", false
+}
+
+// monthDate builds a date from a month name and a four or two digit year
+func monthDate(monthText, yearText string) (time.Time, string, bool) {
+	month, ok := parseMonthName(monthText)
+	if !ok {
+		return time.Time{}, "", false
+	}
+
+	year, ok := parseYear(yearText)
+	if !ok {
+		return time.Time{}, "", false
+	}
+
+	return time.Date(year, month, 1, 0, 0, 0, 0, time.UTC), PrecisionMonth, true
+}
+
+// numericDate builds a date from a numeric month and year
+func numericDate(monthText, yearText string) (time.Time, string, bool) {
+	month, err := strconv.Atoi(monthText)
+	if err != nil || month < 1 || month > 12 {
+		return time.Time{}, "", false
+	}
+
+	year, err := strconv.Atoi(yearText)
+	if err != nil {
+		return time.Time{}, "", false
+	}
+
+	return time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC), PrecisionMonth, true
+}
+
+// parseMonthName maps full or abbreviated month names to time.Month
+func parseMonthName(text string) (time.Month, bool) {
+	name := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(text)), ".")
+	if len(name) < 3 {
+		return 0, false
+	}
+
+	for m := time.January; m <= time.December; m++ {
+		if strings.HasPrefix(strings.ToLower(m.String()), name[:3]) {
+			return m, true
+		}
+	}
+
+	return 0, false
+}
+
+// parseYear parses "2020" or "'20". Two digit years more than five years in
+// the future are taken to be in the previous century.
+func parseYear(text string) (int, bool) {
+	if strings.HasPrefix(text, "'") {
+		yy, err := strconv.Atoi(text[1:])
+		if err != nil {
+			return 0, false
+		}
+		year := 2000 + yy
+		if year > time.Now().Year()+5 {
+			year -= 100
+		}
+		return year, true
+	}
+
+	year, err := strconv.Atoi(text)
+	if err != nil {
+		return 0, false
+	}
+	return year, true
+}
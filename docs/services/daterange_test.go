@@ -0,0 +1,191 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// dateFormats write a month and year in the ways resumes do
+var dateFormats = []struct {
+	name   string
+	format func(month time.Month, year int) string
+}{
+	{"abbreviated", func(m time.Month, y int) string { return fmt.Sprintf("%s %d", m.String()[:3], y) }},
+	{"full", func(m time.Month, y int) string { return fmt.Sprintf("%s %d", m, y) }},
+	{"abbreviated with dot", func(m time.Month, y int) string { return fmt.Sprintf("%s. %d", m.String()[:3], y) }},
+	{"full with comma", func(m time.Month, y int) string { return fmt.Sprintf("%s, %d", m, y) }},
+	{"upper case", func(m time.Month, y int) string { return strings.ToUpper(fmt.Sprintf("%s %d", m.String()[:3], y)) }},
+	{"lower case", func(m time.Month, y int) string { return strings.ToLower(fmt.Sprintf("%s %d", m, y)) }},
+	{"no space", func(m time.Month, y int) string { return fmt.Sprintf("%s%d", m.String()[:3], y) }},
+	{"two digit year", func(m time.Month, y int) string { return fmt.Sprintf("%s '%02d", m.String()[:3], y%100) }},
+	{"day month year", func(m time.Month, y int) string { return fmt.Sprintf("15 %s %d", m, y) }},
+	{"ordinal day", func(m time.Month, y int) string { return fmt.Sprintf("1st %s %d", m.String()[:3], y) }},
+	{"month day year", func(m time.Month, y int) string { return fmt.Sprintf("%s 15, %d", m, y) }},
+	{"ISO", func(m time.Month, y int) string { return fmt.Sprintf("%d-%02d", y, m) }},
+	{"year slash month", func(m time.Month, y int) string { return fmt.Sprintf("%d/%02d", y, m) }},
+	{"year dot month", func(m time.Month, y int) string { return fmt.Sprintf("%d.%02d", y, m) }},
+	{"month slash year", func(m time.Month, y int) string { return fmt.Sprintf("%02d/%d", m, y) }},
+	{"short month slash year", func(m time.Month, y int) string { return fmt.Sprintf("%d/%d", m, y) }},
+	{"month dash year", func(m time.Month, y int) string { return fmt.Sprintf("%02d-%d", m, y) }},
+	{"month dot year", func(m time.Month, y int) string { return fmt.Sprintf("%02d.%d", m, y) }},
+	{"spaced slash", func(m time.Month, y int) string { return fmt.Sprintf("%d / %d", m, y) }},
+}
+
+var rangeSeparators = []string{" - ", "-", " -- ", " – ", "–", " — ", " − ", " ~ ", " to ", " To ", " until ", " till ", " through ", " thru "}
+
+var presentWords = []string{"Present", "present", "PRESENT", "Current", "Currently", "Now", "Today", "Ongoing", "Till Date", "to date", "Date"}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// checkRange parses text and compares the result with the expected range;
+// a nil end means the range is current
+func checkRange(t *testing.T, text string, start time.Time, end *time.Time, precision string) {
+	t.Helper()
+	dr, ok := ParseDateRange(text)
+	if !ok {
+		t.Errorf("ParseDateRange(%q) found no range", text)
+		return
+	}
+	if !dr.Start.Equal(start) {
+		t.Errorf("ParseDateRange(%q) start = %s, want %s", text, dr.Start.Format("2006-01-02"), start.Format("2006-01-02"))
+	}
+	switch {
+	case end == nil && (dr.End != nil || !dr.IsCurrent):
+		t.Errorf("ParseDateRange(%q) = %+v, want a current range", text, dr)
+	case end != nil && (dr.End == nil || !dr.End.Equal(*end)):
+		t.Errorf("ParseDateRange(%q) end = %v, want %s", text, dr.End, end.Format("2006-01-02"))
+	}
+	if dr.Precision != precision {
+		t.Errorf("ParseDateRange(%q) precision = %s, want %s", text, dr.Precision, precision)
+	}
+}
+
+func TestParseDateRangeFormatPairs(t *testing.T) {
+	start, end := date(2019, time.March, 1), date(2021, time.June, 30)
+	for _, from := range dateFormats {
+		for _, to := range dateFormats {
+			text := from.format(time.March, 2019) + " - " + to.format(time.June, 2021)
+			t.Run(from.name+"/"+to.name, func(t *testing.T) {
+				checkRange(t, text, start, &end, PrecisionMonth)
+			})
+		}
+	}
+}
+
+func TestParseDateRangeSeparators(t *testing.T) {
+	start, end := date(2019, time.March, 1), date(2021, time.June, 30)
+	for _, sep := range rangeSeparators {
+		checkRange(t, "Mar 2019"+sep+"Jun 2021", start, &end, PrecisionMonth)
+		checkRange(t, "March 15, 2019"+sep+"June 30, 2021", start, &end, PrecisionMonth)
+	}
+
+	yearEnd := date(2021, time.December, 31)
+	for _, sep := range rangeSeparators {
+		checkRange(t, "2019"+sep+"2021", date(2019, time.January, 1), &yearEnd, PrecisionYear)
+	}
+}
+
+func TestParseDateRangeCurrent(t *testing.T) {
+	for _, from := range dateFormats {
+		for _, word := range presentWords {
+			checkRange(t, from.format(time.March, 2019)+" - "+word, date(2019, time.March, 1), nil, PrecisionMonth)
+		}
+	}
+	for _, word := range presentWords {
+		checkRange(t, "2019 – "+word, date(2019, time.January, 1), nil, PrecisionYear)
+	}
+}
+
+func TestParseDateRangeMonthNames(t *testing.T) {
+	names := map[time.Month][]string{
+		time.January:   {"Jan", "January"},
+		time.February:  {"Feb", "February"},
+		time.March:     {"Mar", "March"},
+		time.April:     {"Apr", "April"},
+		time.May:       {"May"},
+		time.June:      {"Jun", "June"},
+		time.July:      {"Jul", "July"},
+		time.August:    {"Aug", "August"},
+		time.September: {"Sep", "Sept", "September"},
+		time.October:   {"Oct", "October"},
+		time.November:  {"Nov", "November"},
+		time.December:  {"Dec", "December"},
+	}
+	for month, forms := range names {
+		for _, form := range forms {
+			end := date(2020, month+1, 0)
+			checkRange(t, form+" 2020 - "+form+" 2020", date(2020, month, 1), &end, PrecisionMonth)
+		}
+	}
+}
+
+func TestParseDateRangeInContext(t *testing.T) {
+	tests := []struct {
+		text      string
+		start     time.Time
+		end       *time.Time
+		precision string
+	}{
+		{"Senior Engineer | Acme Corp | Jan 2020 - Present", date(2020, time.January, 1), nil, PrecisionMonth},
+		{"Acme Corp (2016 – 2019)", date(2016, time.January, 1), ptr(date(2019, time.December, 31)), PrecisionYear},
+		{"Software Engineer, Globex, 06/2017 to 08/2018", date(2017, time.June, 1), ptr(date(2018, time.August, 31)), PrecisionMonth},
+		{"Intern — Summer 2015 - Fall 2015", date(2015, time.June, 1), ptr(date(2015, time.September, 30)), PrecisionMonth},
+		{"Spring 2018 to Winter 2019", date(2018, time.March, 1), ptr(date(2019, time.January, 31)), PrecisionMonth},
+		{"Autumn, 2012 – Spring, 2014", date(2012, time.September, 1), ptr(date(2014, time.March, 31)), PrecisionMonth},
+		{"Mar 2019 - 2021", date(2019, time.March, 1), ptr(date(2021, time.December, 31)), PrecisionYear},
+		{"2019 - Jun 2021", date(2019, time.January, 1), ptr(date(2021, time.June, 30)), PrecisionYear},
+		{"Feb 2020 - Feb 2020", date(2020, time.February, 1), ptr(date(2020, time.February, 29)), PrecisionMonth},
+		{"Dec '99 - Jan '01", date(1999, time.December, 1), ptr(date(2001, time.January, 31)), PrecisionMonth},
+		{"Working here since March 2021", date(2021, time.March, 1), nil, PrecisionMonth},
+		{"Since 2015", date(2015, time.January, 1), nil, PrecisionYear},
+		{"Lead Developer\nJuly 2010 — September 2014\nBuilt things", date(2010, time.July, 1), ptr(date(2014, time.September, 30)), PrecisionMonth},
+		{"BSc Computer Science, 2008-2012", date(2008, time.January, 1), ptr(date(2012, time.December, 31)), PrecisionYear},
+		{"Consultant (Oct. 2016 – Currently)", date(2016, time.October, 1), nil, PrecisionMonth},
+		{"Analyst\t01.2014 - 12.2015", date(2014, time.January, 1), ptr(date(2015, time.December, 31)), PrecisionMonth},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			checkRange(t, tt.text, tt.start, tt.end, tt.precision)
+		})
+	}
+}
+
+func TestParseDateRangeRejects(t *testing.T) {
+	for _, text := range []string{
+		"",
+		"Managed a team of 12 engineers",
+		"Call 555-1234",
+		"Version 2.0 - 3.1",
+		"Jun 2021 - Mar 2019",
+		"2021 - 2019",
+		"13/2020 - 14/2021",
+		"Room 1200 - 1300",
+		"Since the beginning",
+	} {
+		if dr, ok := ParseDateRange(text); ok {
+			t.Errorf("ParseDateRange(%q) = %+v, want no range", text, dr)
+		}
+	}
+}
+
+func TestFindDateRanges(t *testing.T) {
+	text := "Acme: Jan 2020 - Present\nGlobex: 2016 - 2019\nInitech: 03/2014 – 12/2015"
+	ranges := FindDateRanges(text)
+	if len(ranges) != 3 {
+		t.Fatalf("FindDateRanges found %d ranges %+v, want 3", len(ranges), ranges)
+	}
+	for i, want := range []int{2020, 2016, 2014} {
+		if ranges[i].Start.Year() != want {
+			t.Errorf("range %d starts in %d, want %d", i, ranges[i].Start.Year(), want)
+		}
+	}
+}
+
+func ptr(t time.Time) *time.Time {
+	return &t
+}
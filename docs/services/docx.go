@@ -0,0 +1,456 @@
+package services
+
+import (
+	"archive/zip"
+	"ats-analyzer/models"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// docxMainContentType marks the main part of a Word document, as opposed to
+// other Office Open XML files such as spreadsheets
+const docxMainContentType = "wordprocessingml.document.main+xml"
+
+// WordprocessingML namespaces, in the transitional form Word writes and
+// the strict form
+const (
+	docxMainNS          = "http://schemas.openxmlformats.org/wordprocessingml/2006/main"
+	docxStrictMainNS    = "http://purl.oclc.org/ooxml/wordprocessingml/main"
+	docxRelationshipsNS = "http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+)
+
+var (
+	docxPagesRegex   = regexp.MustCompile(`<Pages>(\d+)</Pages>`)
+	docxColumnsRegex = regexp.MustCompile(`<w:cols [^>]*w:num="(\d+)"`)
+	docxFontRegex    = regexp.MustCompile(`w:ascii="([^"]+)"`)
+	docxHeadingRegex = regexp.MustCompile(`(?i)^heading\s?(\d)$`)
+	docxPartRegex    = regexp.MustCompile(`^word/(header|footer)\d*\.xml$`)
+)
+
+// docxPart is a part of a Word document holding text
+type docxPart struct {
+	file   *zip.File
+	origin string
+}
+
+// docxRelationships is a part's relationships file, which holds the targets
+// of its hyperlinks
+type docxRelationships struct {
+	Relationships []struct {
+		ID         string `xml:"Id,attr"`
+		Target     string `xml:"Target,attr"`
+		TargetMode string `xml:"TargetMode,attr"`
+	} `xml:"Relationship"`
+}
+
+// docxExtractor reads Word resumes. Besides the body it reads headers,
+// footers, text boxes, footnotes and endnotes, tagging each block with the
+// region it came from, and keeps hyperlink targets.
+type docxExtractor struct{}
+
+// Format describes DOCX
+func (docxExtractor) Format() models.DocumentFormat {
+	return models.DocumentFormat{
+		Name:       "DOCX",
+		Extensions: []string{".docx"},
+		MIMETypes:  []string{"application/vnd.openxmlformats-officedocument.wordprocessingml.document"},
+	}
+}
+
+// Detect matches the extension or an archive whose first entries are in
+// the word/ folder
+func (e docxExtractor) Detect(filename string, head []byte) bool {
+	return bytes.HasPrefix(head, zipMagic) && bytes.Contains(head, []byte("word/")) ||
+		HasExtension(filename, e.Format().Extensions...)
+}
+
+// Validate checks the archive is a Word document of reasonable size,
+// using the page count Word stores in docProps/app.xml when present
+func (docxExtractor) Validate(r io.ReaderAt, size int64, limits UploadLimits) error {
+	reader, err := checkArchive(r, size, "DOCX", limits)
+	if err != nil {
+		return err
+	}
+
+	contentTypes, err := readArchiveEntry(reader, "[Content_Types].xml", maxArchiveMetadataSize)
+	if err != nil {
+		return archiveReadError("DOCX content types", err)
+	}
+	if !bytes.Contains(contentTypes, []byte(docxMainContentType)) {
+		return uploadError(CodeFormatMismatch, "file is not a DOCX document")
+	}
+
+	properties, err := readArchiveEntry(reader, "docProps/app.xml", maxArchiveMetadataSize)
+	if err != nil {
+		return archiveReadError("DOCX properties", err)
+	}
+	if match := docxPagesRegex.FindSubmatch(properties); match != nil {
+		pages, _ := strconv.Atoi(string(match[1]))
+		return checkPageCount(pages, limits)
+	}
+	return nil
+}
+
+// Extract reads every part of the document holding text, headers first so
+// contact details in them lead the text as they lead the page
+func (docxExtractor) Extract(r io.ReaderAt, size int64) ([]models.TextBlock, error) {
+	reader, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	parts, err := docxTextParts(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	var blocks []models.TextBlock
+	// The first-page and default headers often repeat each other
+	seen := make(map[string]bool)
+	for _, part := range parts {
+		partBlocks, err := docxPartBlocks(reader, part)
+		if err != nil {
+			return nil, err
+		}
+		for _, block := range partBlocks {
+			if part.origin == models.OriginHeader || part.origin == models.OriginFooter {
+				key := part.origin + "\x00" + block.Text
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+			}
+			blocks = append(blocks, block)
+		}
+	}
+
+	return blocks, nil
+}
+
+// docxTextParts lists the parts holding text in reading order: headers,
+// the body, footers, footnotes and endnotes
+func docxTextParts(reader *zip.Reader) ([]docxPart, error) {
+	var body *zip.File
+	var headers, footers, notes []docxPart
+	for _, file := range reader.File {
+		switch match := docxPartRegex.FindStringSubmatch(file.Name); {
+		case file.Name == "word/document.xml":
+			body = file
+		case match != nil && match[1] == "header":
+			headers = append(headers, docxPart{file: file, origin: models.OriginHeader})
+		case match != nil:
+			footers = append(footers, docxPart{file: file, origin: models.OriginFooter})
+		case file.Name == "word/footnotes.xml":
+			notes = append(notes, docxPart{file: file, origin: models.OriginFootnote})
+		case file.Name == "word/endnotes.xml":
+			notes = append(notes, docxPart{file: file, origin: models.OriginEndnote})
+		}
+	}
+	if body == nil {
+		return nil, fmt.Errorf("word/document.xml not found")
+	}
+
+	byName := func(parts []docxPart) {
+		sort.Slice(parts, func(i, j int) bool {
+			return parts[i].file.Name < parts[j].file.Name
+		})
+	}
+	byName(headers)
+	byName(footers)
+	byName(notes)
+
+	parts := append(headers, docxPart{file: body, origin: models.OriginBody})
+	parts = append(parts, footers...)
+	return append(parts, notes...), nil
+}
+
+// docxPartBlocks reads the text of one part, resolving its hyperlinks
+// through the part's relationships
+func docxPartBlocks(reader *zip.Reader, part docxPart) ([]models.TextBlock, error) {
+	rels := "word/_rels/" + strings.TrimPrefix(part.file.Name, "word/") + ".rels"
+	links, err := docxLinkTargets(reader, rels)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := part.file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer content.Close()
+
+	return docxToBlocks(content, part.origin, links)
+}
+
+// docxLinkTargets maps the ids of a part's external hyperlinks to their
+// targets
+func docxLinkTargets(reader *zip.Reader, name string) (map[string]string, error) {
+	data, err := readArchiveEntry(reader, name, maxArchiveMetadataSize)
+	if err != nil || data == nil {
+		return nil, err
+	}
+
+	var rels docxRelationships
+	if err := xml.Unmarshal(data, &rels); err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", name, err)
+	}
+
+	links := make(map[string]string)
+	for _, rel := range rels.Relationships {
+		if strings.EqualFold(rel.TargetMode, "External") {
+			links[rel.ID] = rel.Target
+		}
+	}
+	return links, nil
+}
+
+// docxScope is the text being collected in one flow of text: a part, or a
+// text box inside it
+type docxScope struct {
+	w blockWriter
+	// cellIndex counts the cells of the current table row
+	cellIndex int
+	// link is the target of the hyperlink being read and linkStart where
+	// its text starts
+	link      string
+	linkStart int
+}
+
+// docxToBlocks walks a WordprocessingML part and collects its visible text.
+// Paragraph styles give headings and numbering gives list items; table rows
+// are flattened to one block per row. Text boxes are collected after the
+// part's own text.
+func docxToBlocks(r io.Reader, origin string, links map[string]string) ([]models.TextBlock, error) {
+	decoder := xml.NewDecoder(r)
+	scopes := []*docxScope{{w: blockWriter{origin: origin}}}
+	var boxes []models.TextBlock
+	skipDepth := 0
+	runDepth := 0
+	textDepth := 0
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid DOCX part: %v", err)
+		}
+		scope := scopes[len(scopes)-1]
+		w := &scope.w
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if skipDepth > 0 {
+				skipDepth++
+				continue
+			}
+			// Shapes and charts carry their own paragraphs and text
+			if !isDOCXWordElement(t.Name) && t.Name.Local != "Fallback" {
+				continue
+			}
+
+			switch t.Name.Local {
+			case "Fallback":
+				// Markup for older readers repeats the content it replaces
+				skipDepth = 1
+			case "txbxContent":
+				scopes = append(scopes, &docxScope{w: blockWriter{origin: models.OriginTextBox}})
+			case "p":
+				// Paragraphs inside a cell continue the row
+				if scope.cellIndex == 0 {
+					w.start(models.BlockParagraph, 0)
+				} else if !w.empty() && !w.hasSuffix(" ") {
+					w.write(" ")
+				}
+			case "pStyle":
+				if scope.cellIndex == 0 {
+					if level := docxHeadingLevel(docxAttr(t, "val")); level > 0 {
+						w.kind, w.level = models.BlockHeading, level
+					}
+				}
+			case "outlineLvl":
+				if level, err := strconv.Atoi(docxAttr(t, "val")); err == nil && level < 9 && scope.cellIndex == 0 {
+					w.kind, w.level = models.BlockHeading, level+1
+				}
+			case "numPr":
+				if scope.cellIndex == 0 && w.kind != models.BlockHeading {
+					w.kind, w.level = models.BlockListItem, 1
+				}
+			case "ilvl":
+				if level, err := strconv.Atoi(docxAttr(t, "val")); err == nil && w.kind == models.BlockListItem {
+					w.level = level + 1
+				}
+			case "tr":
+				w.start(models.BlockTableRow, 0)
+				if origin == models.OriginBody && len(scopes) == 1 {
+					w.origin = models.OriginTable
+				}
+				scope.cellIndex = 0
+			case "tc":
+				if scope.cellIndex > 0 {
+					w.write("  ")
+				}
+				scope.cellIndex++
+			case "hyperlink":
+				scope.link = links[docxRelationshipID(t)]
+				scope.linkStart = w.text.Len()
+			case "r":
+				runDepth++
+			case "t":
+				textDepth++
+			case "tab":
+				// Tab stops are declared with the same element name
+				if runDepth > 0 {
+					w.write("\t")
+				}
+			case "br", "cr":
+				if runDepth > 0 {
+					w.write("\n")
+				}
+			case "noBreakHyphen":
+				w.write("-")
+			}
+
+		case xml.EndElement:
+			if skipDepth > 0 {
+				skipDepth--
+				continue
+			}
+			if !isDOCXWordElement(t.Name) {
+				continue
+			}
+
+			switch t.Name.Local {
+			case "txbxContent":
+				if len(scopes) > 1 {
+					boxes = append(boxes, w.finish()...)
+					scopes = scopes[:len(scopes)-1]
+				}
+			case "p":
+				if scope.cellIndex == 0 {
+					w.end()
+				}
+			case "tr":
+				w.end()
+				if len(scopes) == 1 {
+					w.origin = origin
+				}
+				scope.cellIndex = 0
+			case "hyperlink":
+				if scope.link != "" && scope.linkStart <= w.text.Len() {
+					writeLinkTarget(w, scope.link, w.text.String()[scope.linkStart:])
+				}
+				scope.link = ""
+			case "r":
+				runDepth--
+			case "t":
+				textDepth--
+			}
+
+		case xml.CharData:
+			if skipDepth == 0 && textDepth > 0 {
+				w.write(string(t))
+			}
+		}
+	}
+
+	return append(scopes[0].w.finish(), boxes...), nil
+}
+
+// isDOCXWordElement reports whether an element is WordprocessingML
+func isDOCXWordElement(name xml.Name) bool {
+	return name.Space == docxMainNS || name.Space == docxStrictMainNS
+}
+
+// docxHeadingLevel returns the heading level of a paragraph style, or 0 for
+// other styles
+func docxHeadingLevel(style string) int {
+	if strings.EqualFold(style, "Title") {
+		return 1
+	}
+	if match := docxHeadingRegex.FindStringSubmatch(style); match != nil {
+		level, _ := strconv.Atoi(match[1])
+		return level
+	}
+	return 0
+}
+
+// docxAttr returns the value of an element's attribute by local name
+func docxAttr(element xml.StartElement, name string) string {
+	for _, attr := range element.Attr {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// docxRelationshipID returns the relationship an element refers to
+func docxRelationshipID(element xml.StartElement) string {
+	for _, attr := range element.Attr {
+		if attr.Name.Space == docxRelationshipsNS && attr.Name.Local == "id" {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// Inspect reports section columns, images and fonts outside the standard
+// set from the document's XML. Tables, text boxes and headers are found in
+// the extracted blocks.
+func (docxExtractor) Inspect(r io.ReaderAt, size int64) ([]models.FormatIssue, error) {
+	reader, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+	body, err := readArchiveEntry(reader, "word/document.xml", maxArchivePartSize)
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []models.FormatIssue
+	for _, match := range docxColumnsRegex.FindAllSubmatch(body, -1) {
+		if columns, _ := strconv.Atoi(string(match[1])); columns > 1 {
+			issues = append(issues, models.FormatIssue{
+				Code:     IssueMultiColumn,
+				Severity: models.SeverityWarning,
+				Message:  fmt.Sprintf("Document is laid out in %d columns; many ATSs read across columns and mix their text", columns),
+			})
+			break
+		}
+	}
+
+	for _, file := range reader.File {
+		if strings.HasPrefix(file.Name, "word/media/") {
+			issues = append(issues, models.FormatIssue{
+				Code:     IssueImage,
+				Severity: models.SeverityInfo,
+				Message:  "Document contains images; any text inside them cannot be read by an ATS",
+			})
+			break
+		}
+	}
+
+	styles, err := readArchiveEntry(reader, "word/styles.xml", maxArchivePartSize)
+	if err != nil {
+		return issues, err
+	}
+	var fonts []string
+	for _, part := range [][]byte{body, styles} {
+		for _, match := range docxFontRegex.FindAllSubmatch(part, -1) {
+			fonts = append(fonts, string(match[1]))
+		}
+	}
+	if issue := fontFormatIssue(fonts, ""); issue != nil {
+		issues = append(issues, *issue)
+	}
+
+	return issues, nil
+}
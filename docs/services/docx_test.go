@@ -0,0 +1,94 @@
+package services
+
+import (
+	"ats-analyzer/models"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// docxPartXML wraps markup in a WordprocessingML part with the given root
+func docxPartXML(root, body string) string {
+	return `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:` + root + ` xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"
+  xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+  xmlns:mc="http://schemas.openxmlformats.org/markup-compatibility/2006"
+  xmlns:wps="http://schemas.microsoft.com/office/word/2010/wordprocessingShape">` + body + `</w:` + root + `>`
+}
+
+// docxParagraph is a paragraph holding one run of text
+func docxParagraph(text string) string {
+	return `<w:p><w:r><w:t xml:space="preserve">` + text + `</w:t></w:r></w:p>`
+}
+
+func TestDOCXExtractor(t *testing.T) {
+	document := docxPartXML("document", `<w:body>
+<w:p><w:pPr><w:pStyle w:val="Heading1"/></w:pPr><w:r><w:t>Jane Doe</w:t></w:r></w:p>
+<w:p><w:r><w:t>Portfolio:</w:t></w:r><w:r><w:tab/></w:r><w:hyperlink r:id="rId5"><w:r><w:t>my site</w:t></w:r></w:hyperlink><w:r><w:br/><w:t>Go</w:t><w:noBreakHyphen/><w:t>Rust</w:t></w:r></w:p>
+<w:p><w:pPr><w:outlineLvl w:val="1"/></w:pPr><w:r><w:t>Experience</w:t></w:r></w:p>
+<w:p><w:pPr><w:numPr><w:ilvl w:val="0"/><w:numId w:val="1"/></w:numPr></w:pPr><w:r><w:t>Built services</w:t></w:r></w:p>
+<w:p><w:pPr><w:numPr><w:ilvl w:val="1"/><w:numId w:val="1"/></w:numPr></w:pPr><w:r><w:t>Cut latency</w:t></w:r></w:p>
+<w:tbl>
+  <w:tr><w:tc>`+docxParagraph("Acme Corp")+`</w:tc><w:tc>`+docxParagraph("2019 - 2021")+`</w:tc></w:tr>
+  <w:tr><w:tc>`+docxParagraph("Go")+docxParagraph("Kubernetes")+`</w:tc><w:tc>`+docxParagraph("5 years")+`</w:tc></w:tr>
+</w:tbl>
+<w:p><w:r><w:t>Summary</w:t></w:r><w:r><mc:AlternateContent><mc:Choice><w:drawing><wps:txbx><w:txbxContent>`+docxParagraph("Open to relocation")+`</w:txbxContent></wps:txbx></w:drawing></mc:Choice><mc:Fallback><w:pict><w:txbxContent>`+docxParagraph("Open to relocation")+`</w:txbxContent></w:pict></mc:Fallback></mc:AlternateContent></w:r></w:p>
+</w:body>`)
+	header := docxPartXML("hdr", docxParagraph("jane@example.com | +44 7700 900123"))
+	data := buildZip(t,
+		"[Content_Types].xml", `<Types><Override ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/></Types>`,
+		"word/document.xml", document,
+		"word/_rels/document.xml.rels", `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId5" Type="hyperlink" Target="https://jane.dev" TargetMode="External"/></Relationships>`,
+		"word/header1.xml", header,
+		"word/header2.xml", header,
+		"word/footer1.xml", docxPartXML("ftr", docxParagraph("Page 1")),
+		"word/footnotes.xml", docxPartXML("footnotes", `<w:footnote w:id="1">`+docxParagraph("Certified 2020")+`</w:footnote>`),
+	)
+
+	blocks, err := extractBytes(docxExtractor{}, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []models.TextBlock{
+		{Kind: models.BlockParagraph, Text: "jane@example.com | +44 7700 900123", Origin: models.OriginHeader},
+		{Kind: models.BlockHeading, Text: "Jane Doe", Level: 1, Origin: models.OriginBody},
+		{Kind: models.BlockParagraph, Text: "Portfolio:\tmy site (https://jane.dev)\nGo-Rust", Origin: models.OriginBody},
+		{Kind: models.BlockHeading, Text: "Experience", Level: 2, Origin: models.OriginBody},
+		{Kind: models.BlockListItem, Text: "Built services", Level: 1, Origin: models.OriginBody},
+		{Kind: models.BlockListItem, Text: "Cut latency", Level: 2, Origin: models.OriginBody},
+		{Kind: models.BlockTableRow, Text: "Acme Corp  2019 - 2021", Origin: models.OriginTable},
+		{Kind: models.BlockTableRow, Text: "Go Kubernetes  5 years", Origin: models.OriginTable},
+		{Kind: models.BlockParagraph, Text: "Summary", Origin: models.OriginBody},
+		{Kind: models.BlockParagraph, Text: "Open to relocation", Origin: models.OriginTextBox},
+		{Kind: models.BlockParagraph, Text: "Page 1", Origin: models.OriginFooter},
+		{Kind: models.BlockParagraph, Text: "Certified 2020", Origin: models.OriginFootnote},
+	}
+	if !reflect.DeepEqual(blocks, want) {
+		t.Errorf("got  %+v\nwant %+v", blocks, want)
+	}
+}
+
+func TestDOCXExtractorRejectsCorruptFiles(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  []byte
+		error string
+	}{
+		{"not an archive", []byte("Jane Doe, Go developer"), "zip: not a valid zip file"},
+		{"no document", buildZip(t, "word/header1.xml", docxPartXML("hdr", docxParagraph("Jane"))), "word/document.xml not found"},
+		{"malformed document", buildZip(t, "word/document.xml", docxPartXML("document", "<w:body><w:p></w:body>")), "invalid DOCX part"},
+		{"malformed relationships", buildZip(t,
+			"word/document.xml", docxPartXML("document", "<w:body/>"),
+			"word/_rels/document.xml.rels", "<Relationships><Relationship"), "invalid word/_rels/document.xml.rels"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := extractBytes(docxExtractor{}, tt.data)
+			if err == nil || !strings.Contains(err.Error(), tt.error) {
+				t.Errorf("err = %v, want %q", err, tt.error)
+			}
+		})
+	}
+}
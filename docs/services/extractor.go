@@ -0,0 +1,318 @@
+package services
+
+import (
+	"ats-analyzer/models"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// sniffLength is how much of a file extractors see when detecting its format
+const sniffLength = 512
+
+// Extractor reads one document format into text blocks
+type Extractor interface {
+	// Format describes the format for validation and the formats endpoint
+	Format() models.DocumentFormat
+	// Detect reports whether a file is in this format. It is called with
+	// only the file's first bytes to sniff content and with only its name
+	// to match the extension, so either argument may be empty.
+	Detect(filename string, head []byte) bool
+	// Extract reads the document's text in document order
+	Extract(r io.ReaderAt, size int64) ([]models.TextBlock, error)
+}
+
+var (
+	defaultExtractors     *ExtractorRegistry
+	defaultExtractorsOnce sync.Once
+)
+
+// ExtractorRegistry chooses the extractor for a resume file. Extractors
+// registered later take precedence, so a built-in format can be replaced.
+type ExtractorRegistry struct {
+	mu         sync.RWMutex
+	extractors []Extractor
+	limits     UploadLimits
+}
+
+// NewExtractorRegistry creates a registry holding the given extractors
+func NewExtractorRegistry(extractors ...Extractor) *ExtractorRegistry {
+	registry := &ExtractorRegistry{limits: DefaultUploadLimits}
+	for _, extractor := range extractors {
+		registry.Register(extractor)
+	}
+	return registry
+}
+
+// DefaultExtractors returns the process-wide registry used by the parser
+// and upload validation, holding the built-in formats. Formats registered
+// on it are accepted by every endpoint.
+func DefaultExtractors() *ExtractorRegistry {
+	defaultExtractorsOnce.Do(func() {
+		defaultExtractors = NewExtractorRegistry(builtinExtractors()...)
+	})
+	return defaultExtractors
+}
+
+// Register adds an extractor to the registry
+func (r *ExtractorRegistry) Register(extractor Extractor) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.extractors = append(r.extractors, extractor)
+}
+
+// Supports reports whether a file name has an extension some extractor reads
+func (r *ExtractorRegistry) Supports(filename string) bool {
+	return r.byName(filename) != nil
+}
+
+// Detect returns the extractor for a document. Its content is sniffed first
+// so a mislabelled file still reaches the right extractor; the extension of
+// name decides when no extractor recognises the content.
+func (r *ExtractorRegistry) Detect(name string, reader io.ReaderAt, size int64) (Extractor, error) {
+	head, err := readHead(reader, size)
+	if err != nil {
+		return nil, err
+	}
+
+	if extractor := r.byContent(head); extractor != nil {
+		return extractor, nil
+	}
+	if extractor := r.byName(name); extractor != nil {
+		return extractor, nil
+	}
+	return nil, fmt.Errorf("unsupported file format: %s", strings.ToLower(filepath.Ext(name)))
+}
+
+// Extract detects a document's format and reads its text blocks
+func (r *ExtractorRegistry) Extract(name string, reader io.ReaderAt, size int64) ([]models.TextBlock, error) {
+	extractor, err := r.Detect(name, reader, size)
+	if err != nil {
+		return nil, err
+	}
+	return extractor.Extract(reader, size)
+}
+
+// Formats lists the supported formats in registration order
+func (r *ExtractorRegistry) Formats() []models.DocumentFormat {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	formats := make([]models.DocumentFormat, 0, len(r.extractors))
+	for _, extractor := range r.extractors {
+		formats = append(formats, extractor.Format())
+	}
+	return formats
+}
+
+// FormatNames lists the supported format names for messages, e.g.
+// "PDF, DOCX and TXT"
+func (r *ExtractorRegistry) FormatNames() string {
+	formats := r.Formats()
+	names := make([]string, 0, len(formats))
+	for _, format := range formats {
+		names = append(names, format.Name)
+	}
+
+	if len(names) < 2 {
+		return strings.Join(names, "")
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+}
+
+// byContent returns the newest extractor that recognises the content
+func (r *ExtractorRegistry) byContent(head []byte) Extractor {
+	if len(head) == 0 {
+		return nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for i := len(r.extractors) - 1; i >= 0; i-- {
+		if r.extractors[i].Detect("", head) {
+			return r.extractors[i]
+		}
+	}
+	return nil
+}
+
+// byName returns the newest extractor that accepts the file name
+func (r *ExtractorRegistry) byName(filename string) Extractor {
+	if filename == "" {
+		return nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for i := len(r.extractors) - 1; i >= 0; i-- {
+		if r.extractors[i].Detect(filename, nil) {
+			return r.extractors[i]
+		}
+	}
+	return nil
+}
+
+// readHead reads the first bytes of a document for content sniffing
+func readHead(r io.ReaderAt, size int64) ([]byte, error) {
+	if size > sniffLength {
+		size = sniffLength
+	}
+
+	head := make([]byte, size)
+	n, err := r.ReadAt(head, 0)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	return head[:n], nil
+}
+
+// readAll reads a whole document
+func readAll(r io.ReaderAt, size int64) ([]byte, error) {
+	return io.ReadAll(io.NewSectionReader(r, 0, size))
+}
+
+// HasExtension reports whether a file name ends in one of the extensions,
+// ignoring case. Extractors use it to match file names in Detect.
+func HasExtension(filename string, extensions ...string) bool {
+	if filename == "" {
+		return false
+	}
+
+	ext := strings.ToLower(filepath.Ext(filename))
+	for _, candidate := range extensions {
+		if ext == candidate {
+			return true
+		}
+	}
+	return false
+}
+
+// BlocksToText renders text blocks as plain text, one block per line.
+// Headings are preceded by a blank line so they stand apart as section
+// titles and list items keep a bullet indented by their depth.
+func BlocksToText(blocks []models.TextBlock) string {
+	var text strings.Builder
+	for i, block := range blocks {
+		switch block.Kind {
+		case models.BlockHeading:
+			if i > 0 {
+				text.WriteString("\n")
+			}
+		case models.BlockListItem:
+			if block.Level > 1 {
+				text.WriteString(strings.Repeat("  ", block.Level-1))
+			}
+			text.WriteString("• ")
+		}
+		text.WriteString(block.Text)
+		text.WriteString("\n")
+	}
+	return text.String()
+}
+
+// BlocksFromText splits plain text into paragraph blocks, one per non-empty
+// line, for formats without structure of their own
+func BlocksFromText(text string) []models.TextBlock {
+	var blocks []models.TextBlock
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimRight(line, " \t")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		blocks = append(blocks, models.TextBlock{Kind: models.BlockParagraph, Text: line})
+	}
+	return blocks
+}
+
+// blockWriter collects text blocks for extractors that walk a document
+// tree. Text is written into the current block until it is flushed.
+type blockWriter struct {
+	blocks []models.TextBlock
+	kind   string
+	level  int
+	// origin tags the blocks written, for formats with several regions
+	origin string
+	text   strings.Builder
+}
+
+// start flushes the current block and begins one of the given kind
+func (w *blockWriter) start(kind string, level int) {
+	w.flush()
+	w.kind, w.level = kind, level
+}
+
+// write appends text to the current block
+func (w *blockWriter) write(s string) {
+	w.text.WriteString(s)
+}
+
+// flush ends the current block. An empty block keeps its kind, so a
+// paragraph nested inside a list item still produces a list item.
+func (w *blockWriter) flush() {
+	text := strings.TrimSpace(w.text.String())
+	if text == "" {
+		w.text.Reset()
+		return
+	}
+
+	// Trim trailing spaces left on lines broken inside the block
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+
+	kind := w.kind
+	if kind == "" {
+		kind = models.BlockParagraph
+	}
+	w.blocks = append(w.blocks, models.TextBlock{Kind: kind, Text: strings.Join(lines, "\n"), Level: w.level, Origin: w.origin})
+	w.kind, w.level = "", 0
+	w.text.Reset()
+}
+
+// end flushes the current block and forgets its kind, closing the element
+// that started it even when it held no text
+func (w *blockWriter) end() {
+	w.flush()
+	w.kind, w.level = "", 0
+}
+
+// hasSuffix reports whether the current block ends with s
+func (w *blockWriter) hasSuffix(s string) bool {
+	return strings.HasSuffix(w.text.String(), s)
+}
+
+// empty reports whether nothing has been written to the current block
+func (w *blockWriter) empty() bool {
+	return w.text.Len() == 0
+}
+
+// finish flushes the last block and returns all blocks
+func (w *blockWriter) finish() []models.TextBlock {
+	w.flush()
+	return w.blocks
+}
+
+// writeLinkTarget appends a link's target after its label unless the label
+// already shows it, so profile URLs survive extraction
+func writeLinkTarget(w *blockWriter, href, label string) {
+	href = strings.TrimPrefix(href, "mailto:")
+	if href == "" || strings.HasPrefix(href, "#") || strings.HasPrefix(href, "javascript:") {
+		return
+	}
+
+	bare := strings.TrimPrefix(strings.TrimPrefix(href, "https://"), "http://")
+	bare = strings.TrimSuffix(strings.TrimPrefix(bare, "www."), "/")
+
+	label = strings.TrimSpace(label)
+	switch {
+	case label == "":
+		w.write(href)
+	case strings.Contains(label, bare):
+		// The target is already visible
+	default:
+		w.write(" (" + href + ")")
+	}
+}
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"ats-analyzer/models"
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// stubExtractor is a format known only by its extension and an optional
+// content prefix
+type stubExtractor struct {
+	name   string
+	ext    string
+	prefix string
+}
+
+func (e stubExtractor) Format() models.DocumentFormat {
+	return models.DocumentFormat{Name: e.name, Extensions: []string{e.ext}}
+}
+
+func (e stubExtractor) Detect(filename string, head []byte) bool {
+	return e.prefix != "" && bytes.HasPrefix(head, []byte(e.prefix)) || HasExtension(filename, e.ext)
+}
+
+func (e stubExtractor) Extract(r io.ReaderAt, size int64) ([]models.TextBlock, error) {
+	return []models.TextBlock{{Kind: models.BlockParagraph, Text: e.name}}, nil
+}
+
+func TestExtractorRegistryDetect(t *testing.T) {
+	registry := NewExtractorRegistry(
+		stubExtractor{name: "PDF", ext: ".pdf", prefix: "%PDF-"},
+		stubExtractor{name: "TXT", ext: ".txt"},
+		stubExtractor{name: "Notes", ext: ".notes", prefix: "NOTES"},
+	)
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"resume.pdf", "%PDF-1.7", "PDF"},
+		{"RESUME.TXT", "Jane Doe", "TXT"},
+		{"resume.txt", "%PDF-1.7", "PDF"},
+		{"resume", "NOTES v1", "Notes"},
+		{"resume.notes", "", "Notes"},
+		{"resume.exe", "MZ", ""},
+		{"", "Jane Doe", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			extractor, err := registry.Detect(tt.name, strings.NewReader(tt.content), int64(len(tt.content)))
+			if tt.want == "" {
+				if err == nil {
+					t.Errorf("detected %s, want an error", extractor.Format().Name)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := extractor.Format().Name; got != tt.want {
+				t.Errorf("detected %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractorRegistryLaterRegistrationWins(t *testing.T) {
+	registry := NewExtractorRegistry(stubExtractor{name: "TXT", ext: ".txt"}, stubExtractor{name: "PDF", ext: ".pdf"})
+	if got := registry.FormatNames(); got != "TXT and PDF" {
+		t.Errorf("FormatNames() = %q, want %q", got, "TXT and PDF")
+	}
+
+	registry.Register(stubExtractor{name: "Plain", ext: ".txt"})
+	blocks, err := registry.Extract("resume.txt", strings.NewReader("Jane"), 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blocks) != 1 || blocks[0].Text != "Plain" {
+		t.Errorf("Extract used %+v, want the Plain extractor", blocks)
+	}
+
+	var names []string
+	for _, format := range registry.Formats() {
+		names = append(names, format.Name)
+	}
+	if want := []string{"TXT", "PDF", "Plain"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("Formats() = %v, want %v", names, want)
+	}
+	if got := registry.FormatNames(); got != "TXT, PDF and Plain" {
+		t.Errorf("FormatNames() = %q, want %q", got, "TXT, PDF and Plain")
+	}
+}
+
+func TestDefaultExtractorsSupportBuiltinFormats(t *testing.T) {
+	for _, name := range []string{"a.pdf", "a.docx", "a.odt", "a.html", "a.htm", "a.txt", "a.md", "a.markdown", "a.rtf"} {
+		if !DefaultExtractors().Supports(name) {
+			t.Errorf("%s is not supported", name)
+		}
+	}
+	for _, name := range []string{"a.doc", "a.pages", "a", ""} {
+		if DefaultExtractors().Supports(name) {
+			t.Errorf("%s is supported", name)
+		}
+	}
+}
+
+func TestBlocksText(t *testing.T) {
+	blocks := []models.TextBlock{
+		{Kind: models.BlockHeading, Text: "Jane Doe", Level: 1},
+		{Kind: models.BlockParagraph, Text: "London"},
+		{Kind: models.BlockHeading, Text: "Experience", Level: 2},
+		{Kind: models.BlockListItem, Text: "Built services", Level: 1},
+		{Kind: models.BlockListItem, Text: "Cut latency", Level: 2},
+		{Kind: models.BlockTableRow, Text: "Go  5"},
+	}
+	want := "Jane Doe\nLondon\n\nExperience\n• Built services\n  • Cut latency\nGo  5\n"
+	if got := BlocksToText(blocks); got != want {
+		t.Errorf("BlocksToText() = %q, want %q", got, want)
+	}
+
+	if got := BlocksFromText("Jane Doe  \n\n  \n\tLondon\n"); !reflect.DeepEqual(got, paragraphs("Jane Doe", "\tLondon")) {
+		t.Errorf("BlocksFromText() = %+v", got)
+	}
+}
@@ -0,0 +1,228 @@
+package services
+
+import (
+	"ats-analyzer/models"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ledongthuc/pdf"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	pdfMagic = []byte("%PDF-")
+	zipMagic = []byte("PK\x03\x04")
+)
+
+// builtinExtractors are the formats every registry starts with, in the
+// order they are listed to users
+func builtinExtractors() []Extractor {
+	return []Extractor{
+		pdfExtractor{mode: os.Getenv(PDFModeEnv)},
+		docxExtractor{},
+		odtExtractor{},
+		htmlExtractor{},
+		txtExtractor{},
+		markdownExtractor{},
+		rtfExtractor{},
+	}
+}
+
+// PDFModeEnv names the environment variable selecting how PDF text is
+// extracted
+const PDFModeEnv = "PDF_EXTRACTION_MODE"
+
+// PDF extraction modes
+const (
+	// PDFModeLayout rebuilds lines, columns and headings from glyph positions
+	PDFModeLayout = "layout"
+	// PDFModePlain uses the text the PDF reader assembles for each page
+	PDFModePlain = "plain"
+)
+
+// pdfExtractor reads the text layer of PDF resumes
+type pdfExtractor struct {
+	mode string
+}
+
+// Format describes PDF
+func (pdfExtractor) Format() models.DocumentFormat {
+	return models.DocumentFormat{
+		Name:       "PDF",
+		Extensions: []string{".pdf"},
+		MIMETypes:  []string{"application/pdf"},
+	}
+}
+
+// Detect matches the extension or the %PDF- header, which readers accept
+// anywhere near the start of the file
+func (e pdfExtractor) Detect(filename string, head []byte) bool {
+	return bytes.Contains(head, pdfMagic) || HasExtension(filename, e.Format().Extensions...)
+}
+
+// Validate checks the PDF header and the page count
+func (pdfExtractor) Validate(r io.ReaderAt, size int64, limits UploadLimits) (err error) {
+	// The PDF reader panics on some malformed files
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = uploadError(CodeCorruptFile, "failed to read PDF document: %v", recovered)
+		}
+	}()
+
+	head, err := readHead(r, size)
+	if err != nil {
+		return err
+	}
+	if !bytes.Contains(head, pdfMagic) {
+		return uploadError(CodeFormatMismatch, "file is not a PDF document")
+	}
+
+	reader, err := pdf.NewReader(r, size)
+	if isPDFEncryptionError(err) {
+		// Reported as unreadable once parsed, rather than rejected
+		return nil
+	}
+	if err != nil {
+		return uploadError(CodeCorruptFile, "failed to open PDF document: %v", err)
+	}
+
+	return checkPageCount(reader.NumPage(), limits)
+}
+
+// isPDFEncryptionError reports whether the PDF reader failed because the
+// document is encrypted with a password or a scheme it cannot decrypt.
+// Documents encrypted only to restrict editing open normally.
+func isPDFEncryptionError(err error) bool {
+	return err != nil && (errors.Is(err, pdf.ErrInvalidPassword) || strings.Contains(err.Error(), "encryption"))
+}
+
+// Extract reads the text of every page. In layout mode lines are rebuilt
+// from glyph positions in reading order, falling back to the reader's plain
+// text when the page content cannot be interpreted.
+func (e pdfExtractor) Extract(r io.ReaderAt, size int64) ([]models.TextBlock, error) {
+	reader, err := pdf.NewReader(r, size)
+	if isPDFEncryptionError(err) {
+		return nil, unreadable(ReasonEncrypted,
+			"The resume PDF is password-protected, so an ATS cannot read it. Upload a copy saved without a password.")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if e.mode != PDFModePlain {
+		blocks, err := pdfLayoutBlocks(reader)
+		if err == nil && len(blocks) > 0 {
+			return blocks, nil
+		}
+		if err != nil {
+			logrus.Warnf("Falling back to plain PDF text: %v", err)
+		}
+	}
+
+	var text strings.Builder
+	totalPages := reader.NumPage()
+
+	for i := 1; i <= totalPages; i++ {
+		page := reader.Page(i)
+		if page.V.IsNull() {
+			continue
+		}
+
+		pageText, err := page.GetPlainText(nil)
+		if err != nil {
+			continue
+		}
+		text.WriteString(pageText)
+		text.WriteString("\n")
+	}
+
+	return BlocksFromText(text.String()), nil
+}
+
+// Inspect reports pages that are only images, images alongside text and
+// fonts outside the standard set
+func (pdfExtractor) Inspect(r io.ReaderAt, size int64) (issues []models.FormatIssue, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("failed to read PDF document: %v", recovered)
+		}
+	}()
+
+	reader, err := pdf.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	var imageOnly, withImages []int
+	var fonts []string
+	for i := 1; i <= reader.NumPage(); i++ {
+		page := reader.Page(i)
+		if page.V.IsNull() {
+			continue
+		}
+		for _, name := range page.Fonts() {
+			fonts = append(fonts, page.Font(name).BaseFont())
+		}
+
+		if pdfPageImages(page) == 0 {
+			continue
+		}
+		glyphs, err := pdfPageGlyphs(page)
+		if err != nil {
+			continue
+		}
+		if pdfTextLength(glyphs) == 0 {
+			imageOnly = append(imageOnly, i)
+		} else {
+			withImages = append(withImages, i)
+		}
+	}
+
+	if len(imageOnly) > 0 {
+		issues = append(issues, models.FormatIssue{
+			Code:     IssueImageOnlyPage,
+			Severity: models.SeverityError,
+			Message:  "Page has no text, only images; an ATS cannot read scanned or image-only pages",
+			Location: pagesLocation(imageOnly),
+		})
+	}
+	if len(withImages) > 0 {
+		issues = append(issues, models.FormatIssue{
+			Code:     IssueImage,
+			Severity: models.SeverityInfo,
+			Message:  "Document contains images; any text inside them cannot be read by an ATS",
+			Location: pagesLocation(withImages),
+		})
+	}
+	if issue := fontFormatIssue(fonts, ""); issue != nil {
+		issues = append(issues, *issue)
+	}
+	return issues, nil
+}
+
+// pdfPageImages counts the images a page draws directly
+func pdfPageImages(page pdf.Page) int {
+	objects := page.Resources().Key("XObject")
+	count := 0
+	for _, name := range objects.Keys() {
+		if objects.Key(name).Key("Subtype").Name() == "Image" {
+			count++
+		}
+	}
+	return count
+}
+
+// pdfTextLength counts the glyphs on a page that are not whitespace
+func pdfTextLength(glyphs []pdf.Text) int {
+	count := 0
+	for _, glyph := range glyphs {
+		if strings.TrimSpace(glyph.S) != "" {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,309 @@
+package services
+
+import (
+	"ats-analyzer/models"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Codes identifying formatting issues, returned to clients with each issue
+const (
+	IssueTable            = "table"
+	IssueTextBox          = "text_box"
+	IssueContactInHeader  = "contact_in_header_footer"
+	IssueImage            = "image"
+	IssueImageOnlyPage    = "image_only_page"
+	IssueNonstandardFont  = "nonstandard_font"
+	IssueMultiColumn      = "multi_column"
+	IssueUnusualHeading   = "unusual_heading"
+	IssueNoHeadings       = "no_section_headings"
+	IssueSpecialCharacter = "special_characters"
+	IssueMissingEmail     = "missing_email"
+	IssueMissingPhone     = "missing_phone"
+	IssueMissingLinkedIn  = "missing_linkedin"
+	IssueMissingLocation  = "missing_location"
+	IssueMissingSections  = "missing_sections"
+	IssueMissingSkills    = "missing_skills"
+	IssueTooLong          = "too_long"
+)
+
+var (
+	tableBorderRegex  = regexp.MustCompile(`[│┌┐└┘├┤┬┴┼]`)
+	contactEmailRegex = regexp.MustCompile(`[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}`)
+)
+
+// standardFonts are font families every ATS and operating system handles,
+// keyed by normalizeFontName
+var standardFonts = map[string]bool{
+	"aptos":            true,
+	"arial":            true,
+	"bookantiqua":      true,
+	"calibri":          true,
+	"calibrilight":     true,
+	"cambria":          true,
+	"century":          true,
+	"centurygothic":    true,
+	"courier":          true,
+	"couriernew":       true,
+	"garamond":         true,
+	"georgia":          true,
+	"helvetica":        true,
+	"liberationsans":   true,
+	"liberationserif":  true,
+	"palatino":         true,
+	"palatinolinotype": true,
+	"segoeui":          true,
+	"symbol":           true,
+	"tahoma":           true,
+	"times":            true,
+	"timesnewroman":    true,
+	"trebuchetms":      true,
+	"verdana":          true,
+	"wingdings":        true,
+	"zapfdingbats":     true,
+}
+
+// otherHeadings are common resume headings, keyed by normalizeHeading,
+// that ATSs recognise but the parser does not segment
+var otherHeadings = map[string]bool{
+	"activities":             true,
+	"additional information": true,
+	"contact":                true,
+	"contact information":    true,
+	"hobbies":                true,
+	"interests":              true,
+	"languages":              true,
+	"links":                  true,
+	"memberships":            true,
+	"references":             true,
+}
+
+// FormatInspector is implemented by extractors that can report formatting
+// an ATS may struggle with from the document's own structure, such as text
+// boxes or pages that are only images
+type FormatInspector interface {
+	Inspect(r io.ReaderAt, size int64) ([]models.FormatIssue, error)
+}
+
+// inspectFormat runs the extractor's format inspection, if it has one. A
+// document that cannot be inspected is still analysed from its text.
+func inspectFormat(extractor Extractor, r io.ReaderAt, size int64) []models.FormatIssue {
+	inspector, ok := extractor.(FormatInspector)
+	if !ok {
+		return nil
+	}
+
+	issues, err := inspector.Inspect(r, size)
+	if err != nil {
+		logrus.Warnf("Failed to inspect %s formatting: %v", extractor.Format().Name, err)
+	}
+	return issues
+}
+
+// blockFormatIssues finds tables, columns, text boxes, contact details in
+// page headers and footers, and table-drawing characters in the extracted
+// blocks
+func blockFormatIssues(blocks []models.TextBlock) []models.FormatIssue {
+	var issues []models.FormatIssue
+
+	tables, boxes, borders := false, false, false
+	var columnPages []int
+	var contactRegions []string
+	for _, block := range blocks {
+		if block.Kind == models.BlockTableRow {
+			tables = true
+		}
+		if block.Origin == models.OriginTextBox {
+			boxes = true
+		}
+		if (block.Origin == models.OriginHeader || block.Origin == models.OriginFooter) && hasContactDetails(block.Text) {
+			if n := len(contactRegions); n == 0 || contactRegions[n-1] != block.Origin {
+				contactRegions = append(contactRegions, block.Origin)
+			}
+		}
+		if block.Layout != nil && block.Layout.Column > 1 {
+			if n := len(columnPages); n == 0 || columnPages[n-1] != block.Layout.Page {
+				columnPages = append(columnPages, block.Layout.Page)
+			}
+		}
+		if tableBorderRegex.MatchString(block.Text) {
+			borders = true
+		}
+	}
+
+	if tables {
+		issues = append(issues, models.FormatIssue{
+			Code:     IssueTable,
+			Severity: models.SeverityWarning,
+			Message:  "Document contains tables, which many ATSs read out of order",
+		})
+	}
+	if boxes {
+		issues = append(issues, models.FormatIssue{
+			Code:     IssueTextBox,
+			Severity: models.SeverityError,
+			Message:  "Document contains text boxes, whose text many ATSs skip",
+		})
+	}
+	for _, region := range contactRegions {
+		issues = append(issues, models.FormatIssue{
+			Code:     IssueContactInHeader,
+			Severity: models.SeverityError,
+			Message:  fmt.Sprintf("Contact details are in the page %s, which many ATSs ignore", region),
+			Location: region,
+		})
+	}
+	if len(columnPages) > 0 {
+		issues = append(issues, models.FormatIssue{
+			Code:     IssueMultiColumn,
+			Severity: models.SeverityWarning,
+			Message:  "Multi-column layout detected; many ATSs read across columns and mix their text",
+			Location: pagesLocation(columnPages),
+		})
+	}
+	if borders {
+		issues = append(issues, models.FormatIssue{
+			Code:     IssueSpecialCharacter,
+			Severity: models.SeverityWarning,
+			Message:  "Document contains table borders or special formatting characters",
+		})
+	}
+
+	return issues
+}
+
+// headingFormatIssues reports resumes without any standard section heading,
+// and headings styled like section headings that no ATS would recognise.
+// Headings before the first recognised section, such as the candidate's
+// name, and those nested below section level are left alone.
+func headingFormatIssues(resume *models.Resume, blocks []models.TextBlock) []models.FormatIssue {
+	if !isSegmented(resume) {
+		return []models.FormatIssue{{
+			Code:     IssueNoHeadings,
+			Severity: models.SeverityWarning,
+			Message:  "No standard section headings found; use headings such as Experience, Education and Skills",
+		}}
+	}
+
+	var issues []models.FormatIssue
+	sectionLevel := 0
+	for _, block := range blocks {
+		if block.Kind != models.BlockHeading {
+			continue
+		}
+		if _, _, ok := detectHeading(block.Text); ok {
+			if sectionLevel == 0 {
+				sectionLevel = block.Level
+			}
+			continue
+		}
+		if sectionLevel == 0 || block.Level > sectionLevel || otherHeadings[normalizeHeading(block.Text)] {
+			continue
+		}
+
+		issues = append(issues, models.FormatIssue{
+			Code:     IssueUnusualHeading,
+			Severity: models.SeverityInfo,
+			Message:  fmt.Sprintf("Section heading %q is not a standard heading and may not be recognised", strings.TrimSpace(block.Text)),
+			Location: blockLocation(block),
+		})
+	}
+	return issues
+}
+
+// hasFormatIssue reports whether issues include one with the given code
+func hasFormatIssue(issues []models.FormatIssue, code string) bool {
+	for _, issue := range issues {
+		if issue.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// fontFormatIssue reports the fonts outside standardFonts, or nil when all
+// are standard
+func fontFormatIssue(fonts []string, location string) *models.FormatIssue {
+	seen := make(map[string]bool)
+	var unusual []string
+	for _, font := range fonts {
+		family := normalizeFontName(font)
+		if family == "" || standardFonts[family] || seen[family] {
+			continue
+		}
+		seen[family] = true
+		unusual = append(unusual, trimFontSubset(font))
+	}
+	if len(unusual) == 0 {
+		return nil
+	}
+
+	sort.Strings(unusual)
+	return &models.FormatIssue{
+		Code:     IssueNonstandardFont,
+		Severity: models.SeverityInfo,
+		Message:  fmt.Sprintf("Uses non-standard fonts (%s); common fonts such as Arial or Calibri are safest", strings.Join(unusual, ", ")),
+		Location: location,
+	}
+}
+
+// normalizeFontName reduces a font name to its family, dropping PDF subset
+// prefixes, styles and spaces: "ABCDEF+TimesNewRomanPS-BoldMT" becomes
+// "timesnewroman"
+func normalizeFontName(name string) string {
+	name = trimFontSubset(name)
+	if i := strings.IndexAny(name, ",-"); i > 0 {
+		name = name[:i]
+	}
+	name = strings.ToLower(strings.ReplaceAll(name, " ", ""))
+	for _, suffix := range []string{"psmt", "mt", "ps"} {
+		if strings.HasSuffix(name, suffix) && len(name) > len(suffix) {
+			return strings.TrimSuffix(name, suffix)
+		}
+	}
+	return name
+}
+
+// trimFontSubset drops the six-letter prefix PDFs give embedded subsets of
+// a font, as in "ABCDEF+Arial"
+func trimFontSubset(name string) string {
+	if i := strings.Index(name, "+"); i == 6 {
+		return name[i+1:]
+	}
+	return name
+}
+
+// hasContactDetails reports whether text holds an email address or phone
+// number
+func hasContactDetails(text string) bool {
+	if contactEmailRegex.MatchString(text) {
+		return true
+	}
+	_, ok := findPhone(text, "", true)
+	return ok
+}
+
+// blockLocation describes where a block is, for formats with a layout
+func blockLocation(block models.TextBlock) string {
+	if block.Layout == nil {
+		return ""
+	}
+	return fmt.Sprintf("page %d", block.Layout.Page)
+}
+
+// pagesLocation describes a list of pages, e.g. "page 1" or "pages 1, 3"
+func pagesLocation(pages []int) string {
+	if len(pages) == 1 {
+		return fmt.Sprintf("page %d", pages[0])
+	}
+	numbers := make([]string, len(pages))
+	for i, page := range pages {
+		numbers[i] = fmt.Sprint(page)
+	}
+	return "pages " + strings.Join(numbers, ", ")
+}
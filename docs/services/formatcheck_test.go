@@ -0,0 +1,192 @@
+package services
+
+import (
+	"ats-analyzer/models"
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// issueSummary is the part of a format issue clients act on
+type issueSummary struct {
+	Code     string
+	Severity string
+	Location string
+}
+
+func summarizeIssues(issues []models.FormatIssue) []issueSummary {
+	var summaries []issueSummary
+	for _, issue := range issues {
+		summaries = append(summaries, issueSummary{issue.Code, issue.Severity, issue.Location})
+	}
+	return summaries
+}
+
+func TestBlockFormatIssues(t *testing.T) {
+	column := func(page, column int) *models.BlockLayout {
+		return &models.BlockLayout{Page: page, Column: column}
+	}
+
+	tests := []struct {
+		name   string
+		blocks []models.TextBlock
+		want   []issueSummary
+	}{
+		{
+			name:   "plain paragraphs",
+			blocks: paragraphs("Jane Doe", "jane@example.com"),
+		},
+		{
+			name:   "table",
+			blocks: []models.TextBlock{{Kind: models.BlockTableRow, Text: "Go  5 years", Origin: models.OriginTable}},
+			want:   []issueSummary{{IssueTable, models.SeverityWarning, ""}},
+		},
+		{
+			name:   "text box",
+			blocks: []models.TextBlock{{Kind: models.BlockParagraph, Text: "Open to relocation", Origin: models.OriginTextBox}},
+			want:   []issueSummary{{IssueTextBox, models.SeverityError, ""}},
+		},
+		{
+			name: "contact details in header and footer",
+			blocks: []models.TextBlock{
+				{Kind: models.BlockParagraph, Text: "jane@example.com", Origin: models.OriginHeader},
+				{Kind: models.BlockParagraph, Text: "+44 7700 900123", Origin: models.OriginHeader},
+				{Kind: models.BlockParagraph, Text: "Jane Doe", Origin: models.OriginBody},
+				{Kind: models.BlockParagraph, Text: "Call 020 7946 0958", Origin: models.OriginFooter},
+			},
+			want: []issueSummary{
+				{IssueContactInHeader, models.SeverityError, models.OriginHeader},
+				{IssueContactInHeader, models.SeverityError, models.OriginFooter},
+			},
+		},
+		{
+			name: "header without contact details",
+			blocks: []models.TextBlock{
+				{Kind: models.BlockParagraph, Text: "Jane Doe - Resume", Origin: models.OriginHeader},
+				{Kind: models.BlockParagraph, Text: "Page 1 of 2", Origin: models.OriginFooter},
+			},
+		},
+		{
+			name: "columns",
+			blocks: []models.TextBlock{
+				{Kind: models.BlockParagraph, Text: "Experience", Layout: column(1, 1)},
+				{Kind: models.BlockParagraph, Text: "Skills", Layout: column(1, 2)},
+				{Kind: models.BlockParagraph, Text: "Go", Layout: column(1, 2)},
+				{Kind: models.BlockParagraph, Text: "Education", Layout: column(2, 1)},
+				{Kind: models.BlockParagraph, Text: "Languages", Layout: column(3, 2)},
+			},
+			want: []issueSummary{{IssueMultiColumn, models.SeverityWarning, "pages 1, 3"}},
+		},
+		{
+			name:   "single column layout",
+			blocks: []models.TextBlock{{Kind: models.BlockParagraph, Text: "Experience", Layout: column(1, 1)}},
+		},
+		{
+			name:   "table borders",
+			blocks: paragraphs("┌──────┐", "│ Go   │"),
+			want:   []issueSummary{{IssueSpecialCharacter, models.SeverityWarning, ""}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := summarizeIssues(blockFormatIssues(tt.blocks)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHeadingFormatIssues(t *testing.T) {
+	const body = "Built payment services in Go and Python for a team of eight engineers across three offices.\n"
+
+	tests := []struct {
+		name     string
+		markdown string
+		want     []issueSummary
+	}{
+		{
+			name:     "standard headings",
+			markdown: "# Jane Doe\n## Experience\n" + body + "### Acme Corp\n" + body + "## Education\nBSc Computer Science\n## Languages\nFrench\n",
+		},
+		{
+			name:     "unusual section heading",
+			markdown: "# Jane Doe\n## Experience\n" + body + "## My Journey\n" + body,
+			want:     []issueSummary{{IssueUnusualHeading, models.SeverityInfo, ""}},
+		},
+		{
+			name:     "no section headings",
+			markdown: "# Jane Doe\n" + body + body,
+			want:     []issueSummary{{IssueNoHeadings, models.SeverityWarning, ""}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resume, err := NewParser().ParseResumeFrom("resume.md", strings.NewReader(tt.markdown), int64(len(tt.markdown)), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := summarizeIssues(resume.FormatIssues); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFontFormatIssue(t *testing.T) {
+	tests := []struct {
+		fonts   []string
+		message string
+	}{
+		{fonts: []string{"Arial", "ABCDEF+TimesNewRomanPS-BoldMT", "Calibri Light", "Helvetica,Bold", "SegoeUI"}},
+		{fonts: []string{"", "Symbol"}},
+		{fonts: []string{"Arial", "QWERTY+Lobster-Regular", "Comic Sans MS", "Lobster"}, message: "Comic Sans MS, Lobster-Regular"},
+	}
+
+	for _, tt := range tests {
+		issue := fontFormatIssue(tt.fonts, "page 1")
+		if tt.message == "" {
+			if issue != nil {
+				t.Errorf("%v: got %q, want no issue", tt.fonts, issue.Message)
+			}
+			continue
+		}
+		if issue == nil || issue.Severity != models.SeverityInfo || issue.Location != "page 1" || !strings.Contains(issue.Message, "("+tt.message+")") {
+			t.Errorf("%v: got %+v, want an info issue naming %s", tt.fonts, issue, tt.message)
+		}
+	}
+}
+
+func TestDOCXInspect(t *testing.T) {
+	body := func(extra string) string {
+		return docxPartXML("document", "<w:body>"+docxParagraph("Jane Doe")+extra+"</w:body>")
+	}
+	inspect := func(entries ...string) []issueSummary {
+		data := buildZip(t, entries...)
+		issues, err := docxExtractor{}.Inspect(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return summarizeIssues(issues)
+	}
+
+	if got := inspect("word/document.xml", body(`<w:sectPr><w:cols w:num="1"/></w:sectPr>`)); got != nil {
+		t.Errorf("plain document: got %v, want no issues", got)
+	}
+
+	got := inspect(
+		"word/document.xml", body(`<w:sectPr><w:cols w:space="720" w:num="2"/></w:sectPr>`),
+		"word/media/image1.png", "png",
+		"word/styles.xml", `<w:styles><w:rFonts w:ascii="Calibri"/><w:rFonts w:ascii="Papyrus"/></w:styles>`,
+	)
+	want := []issueSummary{
+		{IssueMultiColumn, models.SeverityWarning, ""},
+		{IssueImage, models.SeverityInfo, ""},
+		{IssueNonstandardFont, models.SeverityInfo, ""},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
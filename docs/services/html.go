@@ -0,0 +1,229 @@
+package services
+
+import (
+	"ats-analyzer/models"
+	"bytes"
+	"io"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// htmlSkipElements hold no visible resume text
+var htmlSkipElements = map[string]bool{
+	"head": true, "script": true, "style": true, "noscript": true, "template": true,
+	"svg": true, "canvas": true, "iframe": true, "object": true, "button": true,
+	"select": true, "option": true,
+}
+
+// htmlBlockElements start a new block
+var htmlBlockElements = map[string]bool{
+	"p": true, "div": true, "section": true, "article": true, "header": true,
+	"footer": true, "main": true, "aside": true, "address": true, "blockquote": true,
+	"pre": true, "ul": true, "ol": true, "dl": true, "dt": true, "dd": true,
+	"table": true, "tr": true, "figure": true, "figcaption": true, "hr": true,
+	"form": true, "fieldset": true, "nav": true,
+}
+
+// htmlHeadingElements become heading blocks for section detection
+var htmlHeadingElements = map[string]bool{
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+}
+
+var (
+	htmlHiddenStyleRegex = regexp.MustCompile(`(?i)display\s*:\s*none|visibility\s*:\s*hidden`)
+	htmlSpaceRegex       = regexp.MustCompile(`[ \t\f\v\x{00a0}]+`)
+)
+
+// htmlExtractor reads HTML resumes, keeping headings, list items and table
+// rows as separate blocks
+type htmlExtractor struct{}
+
+// Format describes HTML
+func (htmlExtractor) Format() models.DocumentFormat {
+	return models.DocumentFormat{
+		Name:       "HTML",
+		Extensions: []string{".html", ".htm"},
+		MIMETypes:  []string{"text/html"},
+	}
+}
+
+// Detect matches the extension or a document starting with an html tag
+func (e htmlExtractor) Detect(filename string, head []byte) bool {
+	head = bytes.ToLower(bytes.TrimSpace(bytes.TrimPrefix(head, []byte{0xEF, 0xBB, 0xBF})))
+	return bytes.HasPrefix(head, []byte("<!doctype html")) || bytes.HasPrefix(head, []byte("<html")) ||
+		HasExtension(filename, e.Format().Extensions...)
+}
+
+// Validate rejects binary content
+func (e htmlExtractor) Validate(r io.ReaderAt, size int64, limits UploadLimits) error {
+	return checkText(r, size, e.Format().Name)
+}
+
+// Extract reads the visible text of an HTML file
+func (htmlExtractor) Extract(r io.ReaderAt, size int64) ([]models.TextBlock, error) {
+	return htmlToBlocks(io.NewSectionReader(r, 0, size))
+}
+
+// htmlToBlocks splits the visible text of an HTML document into blocks.
+// Hidden elements, scripts and styles are dropped; block elements and line
+// breaks end lines, table cells are joined into one row and links keep their
+// target.
+func htmlToBlocks(r io.Reader) ([]models.TextBlock, error) {
+	doc, err := html.Parse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var w blockWriter
+	listDepth := 0
+	// Block elements inside a table row continue the row
+	rowDepth := 0
+
+	var walk func(node *html.Node, pre bool)
+	walk = func(node *html.Node, pre bool) {
+		switch node.Type {
+		case html.TextNode:
+			data := node.Data
+			if !pre {
+				data = htmlSpaceRegex.ReplaceAllString(strings.ReplaceAll(data, "\n", " "), " ")
+				// Leading space is dropped at the start of a line or after
+				// another space
+				if w.empty() || w.hasSuffix("\n") || w.hasSuffix(" ") {
+					data = strings.TrimLeft(data, " ")
+				}
+			}
+			w.write(data)
+			return
+		case html.ElementNode:
+			if htmlSkipElements[node.Data] || isHiddenHTML(node) {
+				return
+			}
+		case html.CommentNode, html.DoctypeNode:
+			return
+		}
+
+		name := node.Data
+		if node.Type != html.ElementNode {
+			name = ""
+		}
+
+		switch {
+		case htmlHeadingElements[name]:
+			w.start(models.BlockHeading, int(name[1]-'0'))
+		case name == "ul" || name == "ol":
+			w.flush()
+			listDepth++
+		case name == "li":
+			w.start(models.BlockListItem, listDepth)
+		case name == "br":
+			w.write("\n")
+			return
+		case name == "tr":
+			w.start(models.BlockTableRow, 0)
+			rowDepth++
+		case name == "td" || name == "th":
+			// Cells are separated by two spaces
+			if hasPrevElement(node) && !w.hasSuffix("  ") {
+				if w.hasSuffix(" ") {
+					w.write(" ")
+				} else {
+					w.write("  ")
+				}
+			}
+		case htmlBlockElements[name]:
+			if rowDepth > 0 {
+				if !w.empty() && !w.hasSuffix(" ") {
+					w.write(" ")
+				}
+			} else {
+				w.flush()
+			}
+		}
+
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			walk(child, pre || name == "pre")
+		}
+
+		switch {
+		case htmlHeadingElements[name] || name == "li":
+			w.end()
+		case name == "ul" || name == "ol":
+			listDepth--
+		case name == "tr":
+			rowDepth--
+			w.end()
+		case htmlBlockElements[name]:
+			if rowDepth == 0 {
+				w.flush()
+			}
+		case name == "a":
+			writeHTMLLinkTarget(&w, node)
+		}
+	}
+	walk(doc, false)
+
+	return w.finish(), nil
+}
+
+// writeHTMLLinkTarget appends an anchor's target after its text
+func writeHTMLLinkTarget(w *blockWriter, node *html.Node) {
+	writeLinkTarget(w, htmlAttr(node, "href"), htmlNodeText(node))
+}
+
+// isHiddenHTML reports whether an element is hidden from readers
+func isHiddenHTML(node *html.Node) bool {
+	if _, ok := htmlAttrOK(node, "hidden"); ok {
+		return true
+	}
+	if strings.EqualFold(htmlAttr(node, "aria-hidden"), "true") {
+		return true
+	}
+	if node.Data == "input" && !strings.EqualFold(htmlAttr(node, "type"), "text") {
+		return true
+	}
+	return htmlHiddenStyleRegex.MatchString(htmlAttr(node, "style"))
+}
+
+// htmlAttr returns an attribute value or an empty string
+func htmlAttr(node *html.Node, key string) string {
+	value, _ := htmlAttrOK(node, key)
+	return value
+}
+
+// htmlAttrOK returns an attribute value and whether it is present
+func htmlAttrOK(node *html.Node, key string) (string, bool) {
+	for _, attr := range node.Attr {
+		if attr.Key == key {
+			return attr.Val, true
+		}
+	}
+	return "", false
+}
+
+// hasPrevElement reports whether an element follows a sibling element
+func hasPrevElement(node *html.Node) bool {
+	for sibling := node.PrevSibling; sibling != nil; sibling = sibling.PrevSibling {
+		if sibling.Type == html.ElementNode {
+			return true
+		}
+	}
+	return false
+}
+
+// htmlNodeText returns the text inside a node
+func htmlNodeText(node *html.Node) string {
+	var text strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			text.WriteString(n.Data)
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(node)
+	return text.String()
+}
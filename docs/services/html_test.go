@@ -0,0 +1,77 @@
+package services
+
+import (
+	"ats-analyzer/models"
+	"reflect"
+	"testing"
+)
+
+func TestHTMLExtractor(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want []models.TextBlock
+	}{
+		{
+			name: "resume",
+			html: `<!DOCTYPE html><html><head><title>CV</title><style>h1 {color: red}</style></head>
+<body>
+  <h1>Jane   Doe</h1>
+  <p>Email: <a href="mailto:jane@example.com">jane@example.com</a> | <a href="https://github.com/jane">GitHub</a><br>London</p>
+  <h2>Experience</h2>
+  <ul>
+    <li>Built <b>Go</b> services
+      <ul><li>Cut latency</li></ul>
+    </li>
+    <li><p>Led a team</p></li>
+  </ul>
+  <table><tr><th>Skill</th><th>Years</th></tr><tr><td><p>Go</p></td><td>5</td></tr></table>
+  <pre>make   test
+go vet</pre>
+</body></html>`,
+			want: []models.TextBlock{
+				{Kind: models.BlockHeading, Text: "Jane Doe", Level: 1},
+				{Kind: models.BlockParagraph, Text: "Email: jane@example.com | GitHub (https://github.com/jane)\nLondon"},
+				{Kind: models.BlockHeading, Text: "Experience", Level: 2},
+				{Kind: models.BlockListItem, Text: "Built Go services", Level: 1},
+				{Kind: models.BlockListItem, Text: "Cut latency", Level: 2},
+				{Kind: models.BlockListItem, Text: "Led a team", Level: 1},
+				{Kind: models.BlockTableRow, Text: "Skill  Years"},
+				{Kind: models.BlockTableRow, Text: "Go  5"},
+				{Kind: models.BlockParagraph, Text: "make   test\ngo vet"},
+			},
+		},
+		{
+			name: "hidden content",
+			html: `<div>Visible</div><div style="display: none">Hidden keywords</div><p hidden>Also hidden</p>
+<script>var skills = "kubernetes"</script><noscript>Enable JS</noscript><button>Download</button>`,
+			want: paragraphs("Visible"),
+		},
+		{
+			name: "fragment",
+			html: "Jane Doe<br/>Go &amp; Rust &nbsp; engineer",
+			want: paragraphs("Jane Doe\nGo & Rust engineer"),
+		},
+		{
+			name: "unclosed tags",
+			html: "<html><body><h2>Skills<p>Go<li>Rust",
+			want: []models.TextBlock{
+				{Kind: models.BlockHeading, Text: "Skills", Level: 2},
+				{Kind: models.BlockParagraph, Text: "Go"},
+				{Kind: models.BlockListItem, Text: "Rust"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blocks, err := extractBytes(htmlExtractor{}, []byte(tt.html))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(blocks, tt.want) {
+				t.Errorf("got  %+v\nwant %+v", blocks, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,355 @@
+package services
+
+import (
+	"ats-analyzer/models"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Environment variables configuring the default job manager
+const (
+	JobWorkersEnv    = "JOB_WORKERS"
+	JobTTLMinutesEnv = "JOB_TTL_MINUTES"
+)
+
+const (
+	// DefaultJobWorkers is the number of jobs run concurrently
+	DefaultJobWorkers = 2
+	// DefaultJobTTL is how long finished jobs are kept for polling
+	DefaultJobTTL = 30 * time.Minute
+	// jobQueueSize limits the jobs waiting for a worker
+	jobQueueSize = 100
+)
+
+var (
+	// ErrQueueFull is returned when no more jobs can be queued
+	ErrQueueFull = errors.New("job queue is full")
+	// ErrJobFinished is returned when cancelling a job that already ended
+	ErrJobFinished = errors.New("job has already finished")
+	// ErrManagerClosed is returned when submitting to a stopped manager
+	ErrManagerClosed = errors.New("job manager is closed")
+)
+
+var (
+	defaultJobManager     *JobManager
+	defaultJobManagerOnce sync.Once
+)
+
+// ProgressFunc reports the current stage of a job and its completion
+// percentage between 0 and 100
+type ProgressFunc func(stage string, percent float64)
+
+// JobFunc performs the work of an asynchronous job. It should stop early
+// when ctx is cancelled.
+type JobFunc func(ctx context.Context, progress ProgressFunc) (interface{}, error)
+
+// queuedJob is a job waiting for a worker
+type queuedJob struct {
+	id  string
+	ctx context.Context
+	fn  JobFunc
+}
+
+// JobManager runs submitted jobs on a bounded worker pool and records their
+// progress in a JobStore
+type JobManager struct {
+	store JobStore
+	queue chan queuedJob
+	ttl   time.Duration
+
+	mu       sync.Mutex
+	cancels  map[string]context.CancelFunc
+	cleanups map[string]func()
+	closed   bool
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewJobManager starts a job manager with the given number of workers.
+// Finished jobs are removed from the store once ttl has passed.
+func NewJobManager(store JobStore, workers int, ttl time.Duration) *JobManager {
+	if workers <= 0 {
+		workers = DefaultJobWorkers
+	}
+	if ttl <= 0 {
+		ttl = DefaultJobTTL
+	}
+
+	m := &JobManager{
+		store:    store,
+		queue:    make(chan queuedJob, jobQueueSize),
+		ttl:      ttl,
+		cancels:  make(map[string]context.CancelFunc),
+		cleanups: make(map[string]func()),
+		stop:     make(chan struct{}),
+	}
+
+	for i := 0; i < workers; i++ {
+		m.wg.Add(1)
+		go m.worker()
+	}
+	go m.janitor()
+
+	return m
+}
+
+// DefaultJobManager returns the process-wide job manager backed by an
+// in-memory store, configured with JOB_WORKERS and JOB_TTL_MINUTES
+func DefaultJobManager() *JobManager {
+	defaultJobManagerOnce.Do(func() {
+		workers, _ := strconv.Atoi(os.Getenv(JobWorkersEnv))
+		minutes, _ := strconv.Atoi(os.Getenv(JobTTLMinutesEnv))
+		defaultJobManager = NewJobManager(NewMemoryJobStore(), workers, time.Duration(minutes)*time.Minute)
+	})
+
+	return defaultJobManager
+}
+
+// Submit queues a job and returns it in the queued state. cleanup, if not
+// nil, releases what the job holds, such as its uploads; it is called once
+// the job ends, including when it is cancelled before it starts, but not
+// when Submit returns an error.
+func (m *JobManager) Submit(jobType string, fn JobFunc, cleanup func()) (*models.Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return nil, ErrManagerClosed
+	}
+
+	id, err := newJobID()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	job := &models.Job{
+		ID:        id,
+		Type:      jobType,
+		Status:    models.JobQueued,
+		Stage:     models.JobQueued,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	select {
+	case m.queue <- queuedJob{id: id, ctx: ctx, fn: fn}:
+	default:
+		cancel()
+		return nil, ErrQueueFull
+	}
+
+	// Workers cannot pick the job up before it is stored since they need
+	// the lock to register it as running
+	if err := m.store.Create(job); err != nil {
+		cancel()
+		return nil, err
+	}
+	m.cancels[id] = cancel
+	if cleanup != nil {
+		m.cleanups[id] = cleanup
+	}
+
+	return job, nil
+}
+
+// Get returns the current state of a job
+func (m *JobManager) Get(id string) (*models.Job, error) {
+	return m.store.Get(id)
+}
+
+// Cancel stops a queued or running job
+func (m *JobManager) Cancel(id string) (*models.Job, error) {
+	// A queued job's cleanup runs once the lock is released
+	var cleanup func()
+	defer func() {
+		if cleanup != nil {
+			cleanup()
+		}
+	}()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	job, err := m.store.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if job.IsFinished() {
+		return job, ErrJobFinished
+	}
+
+	if cancel, ok := m.cancels[id]; ok {
+		cancel()
+	}
+
+	// Running jobs are marked cancelled by their worker once they stop.
+	// Queued ones never run, so they are released straight away.
+	if job.Status == models.JobQueued {
+		delete(m.cancels, id)
+		cleanup = m.cleanups[id]
+		delete(m.cleanups, id)
+		return m.store.Update(id, func(job *models.Job) {
+			m.finishJob(job, models.JobCancelled, nil, "")
+		})
+	}
+
+	return job, nil
+}
+
+// Close stops accepting jobs and waits for running jobs to finish
+func (m *JobManager) Close() {
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		return
+	}
+	m.closed = true
+	close(m.queue)
+	close(m.stop)
+	m.mu.Unlock()
+
+	m.wg.Wait()
+}
+
+// worker runs queued jobs until the queue is closed
+func (m *JobManager) worker() {
+	defer m.wg.Done()
+
+	for queued := range m.queue {
+		if !m.start(queued) {
+			m.release(queued.id)
+			continue
+		}
+
+		result, err := m.run(queued)
+		cancelled := queued.ctx.Err() != nil
+
+		m.mu.Lock()
+		if cancel, ok := m.cancels[queued.id]; ok {
+			cancel()
+			delete(m.cancels, queued.id)
+		}
+		m.mu.Unlock()
+		m.release(queued.id)
+
+		m.store.Update(queued.id, func(job *models.Job) {
+			switch {
+			case cancelled:
+				m.finishJob(job, models.JobCancelled, nil, "")
+			case err != nil:
+				m.finishJob(job, models.JobFailed, nil, err.Error())
+			default:
+				m.finishJob(job, models.JobCompleted, result, "")
+			}
+		})
+	}
+}
+
+// release calls the job's cleanup if it has not been called yet
+func (m *JobManager) release(id string) {
+	m.mu.Lock()
+	cleanup, ok := m.cleanups[id]
+	delete(m.cleanups, id)
+	m.mu.Unlock()
+
+	if ok {
+		cleanup()
+	}
+}
+
+// start marks a queued job as running, skipping jobs cancelled while queued
+func (m *JobManager) start(queued queuedJob) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if queued.ctx.Err() != nil {
+		return false
+	}
+
+	_, err := m.store.Update(queued.id, func(job *models.Job) {
+		job.Status = models.JobRunning
+		job.Stage = models.JobRunning
+	})
+	return err == nil
+}
+
+// run calls the job function, turning a panic into a job failure
+func (m *JobManager) run(queued queuedJob) (result interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			logrus.Errorf("Job %s panicked: %v", queued.id, r)
+			err = fmt.Errorf("internal error: %v", r)
+		}
+	}()
+
+	progress := func(stage string, percent float64) {
+		m.store.Update(queued.id, func(job *models.Job) {
+			if job.IsFinished() {
+				return
+			}
+			job.Stage = stage
+			job.Progress = percent
+		})
+	}
+
+	return queued.fn(queued.ctx, progress)
+}
+
+// finishJob moves a job to a terminal state and sets when it expires
+func (m *JobManager) finishJob(job *models.Job, status string, result interface{}, errMsg string) {
+	expires := time.Now().Add(m.ttl)
+	job.Status = status
+	job.Stage = status
+	job.Result = result
+	job.Error = errMsg
+	job.ExpiresAt = &expires
+	if status == models.JobCompleted {
+		job.Progress = 100
+	}
+}
+
+// janitor periodically removes expired jobs from the store
+func (m *JobManager) janitor() {
+	interval := m.ttl / 2
+	if interval < time.Second {
+		interval = time.Second
+	}
+	if interval > time.Minute {
+		interval = time.Minute
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if removed := m.store.DeleteExpired(time.Now()); removed > 0 {
+				logrus.Infof("Removed %d expired jobs", removed)
+			}
+		case <-m.stop:
+			return
+		}
+	}
+}
+
+// newJobID returns a random job identifier
+func newJobID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate job id: %v", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,246 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"ats-analyzer/models"
+)
+
+// waitForJob polls the manager until the job reaches a final state
+func waitForJob(t *testing.T, m *JobManager, id string) *models.Job {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		job, err := m.Get(id)
+		if err != nil {
+			t.Fatalf("Get(%s): %v", id, err)
+		}
+		if job.IsFinished() {
+			return job
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("job %s did not finish", id)
+	return nil
+}
+
+func TestJobCleanupRunsWhenCancelledWhileQueued(t *testing.T) {
+	m := NewJobManager(NewMemoryJobStore(), 1, time.Minute)
+	defer m.Close()
+
+	// Occupy the only worker so the next job stays queued
+	release := make(chan struct{})
+	blocker, err := m.Submit("test", func(ctx context.Context, progress ProgressFunc) (interface{}, error) {
+		<-release
+		return nil, nil
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ran := false
+	cleaned := 0
+	queued, err := m.Submit("test", func(ctx context.Context, progress ProgressFunc) (interface{}, error) {
+		ran = true
+		return nil, nil
+	}, func() { cleaned++ })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	job, err := m.Cancel(queued.ID)
+	if err != nil {
+		t.Fatalf("Cancel: %v", err)
+	}
+	if job.Status != models.JobCancelled {
+		t.Errorf("status = %s, want %s", job.Status, models.JobCancelled)
+	}
+	if cleaned != 1 {
+		t.Errorf("cleanup called %d times after cancelling, want 1", cleaned)
+	}
+
+	close(release)
+	waitForJob(t, m, blocker.ID)
+	m.Close()
+
+	if ran {
+		t.Error("cancelled job ran")
+	}
+	if cleaned != 1 {
+		t.Errorf("cleanup called %d times in total, want 1", cleaned)
+	}
+}
+
+func TestJobCleanupRunsOnceAfterJobEnds(t *testing.T) {
+	m := NewJobManager(NewMemoryJobStore(), 1, time.Minute)
+	defer m.Close()
+
+	cleaned := make(chan struct{}, 2)
+	job, err := m.Submit("test", func(ctx context.Context, progress ProgressFunc) (interface{}, error) {
+		return "done", nil
+	}, func() { cleaned <- struct{}{} })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	finished := waitForJob(t, m, job.ID)
+	if finished.Status != models.JobCompleted {
+		t.Errorf("status = %s, want %s", finished.Status, models.JobCompleted)
+	}
+	m.Close()
+
+	if len(cleaned) != 1 {
+		t.Errorf("cleanup called %d times, want 1", len(cleaned))
+	}
+}
+
+func TestJobFailures(t *testing.T) {
+	tests := []struct {
+		name  string
+		fn    JobFunc
+		error string
+	}{
+		{
+			name: "error",
+			fn: func(ctx context.Context, progress ProgressFunc) (interface{}, error) {
+				return nil, errors.New("unreadable file")
+			},
+			error: "unreadable file",
+		},
+		{
+			name: "panic",
+			fn: func(ctx context.Context, progress ProgressFunc) (interface{}, error) {
+				panic("boom")
+			},
+			error: "internal error: boom",
+		},
+	}
+
+	m := NewJobManager(NewMemoryJobStore(), 1, time.Minute)
+	defer m.Close()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job, err := m.Submit("test", tt.fn, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			finished := waitForJob(t, m, job.ID)
+			if finished.Status != models.JobFailed || finished.Error != tt.error {
+				t.Errorf("got status %s error %q, want %s error %q", finished.Status, finished.Error, models.JobFailed, tt.error)
+			}
+			if finished.ExpiresAt == nil {
+				t.Error("finished job has no expiry")
+			}
+		})
+	}
+}
+
+func TestJobProgressAndCancelWhileRunning(t *testing.T) {
+	m := NewJobManager(NewMemoryJobStore(), 1, time.Minute)
+	defer m.Close()
+
+	started := make(chan struct{})
+	job, err := m.Submit("test", func(ctx context.Context, progress ProgressFunc) (interface{}, error) {
+		progress("parsing", 40)
+		close(started)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.Status != models.JobQueued {
+		t.Errorf("submitted status = %s, want %s", job.Status, models.JobQueued)
+	}
+
+	<-started
+	running, err := m.Get(job.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if running.Status != models.JobRunning || running.Stage != "parsing" || running.Progress != 40 {
+		t.Errorf("running job = %s/%s/%.0f, want %s/parsing/40", running.Status, running.Stage, running.Progress, models.JobRunning)
+	}
+
+	if _, err := m.Cancel(job.ID); err != nil {
+		t.Fatalf("Cancel: %v", err)
+	}
+	if finished := waitForJob(t, m, job.ID); finished.Status != models.JobCancelled {
+		t.Errorf("status = %s, want %s", finished.Status, models.JobCancelled)
+	}
+	if _, err := m.Cancel(job.ID); err != ErrJobFinished {
+		t.Errorf("cancelling a finished job: err = %v, want %v", err, ErrJobFinished)
+	}
+}
+
+func TestJobManagerClosed(t *testing.T) {
+	m := NewJobManager(NewMemoryJobStore(), 1, time.Minute)
+	m.Close()
+
+	cleaned := false
+	_, err := m.Submit("test", func(ctx context.Context, progress ProgressFunc) (interface{}, error) {
+		return nil, nil
+	}, func() { cleaned = true })
+	if err != ErrManagerClosed {
+		t.Errorf("err = %v, want %v", err, ErrManagerClosed)
+	}
+	if cleaned {
+		t.Error("cleanup called for a job that was never submitted")
+	}
+}
+
+func TestMemoryJobStore(t *testing.T) {
+	store := NewMemoryJobStore()
+	job := &models.Job{ID: "a", Status: models.JobQueued}
+	if err := store.Create(job); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Create(job); err == nil {
+		t.Error("creating a duplicate job succeeded")
+	}
+
+	// The store keeps its own copy
+	job.Status = models.JobFailed
+	got, err := store.Get("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != models.JobQueued {
+		t.Errorf("stored status = %s, want %s", got.Status, models.JobQueued)
+	}
+	got.Status = models.JobFailed
+	if again, _ := store.Get("a"); again.Status != models.JobQueued {
+		t.Error("changing a returned job changed the stored one")
+	}
+
+	updated, err := store.Update("a", func(job *models.Job) { job.Progress = 50 })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Progress != 50 || updated.UpdatedAt.IsZero() {
+		t.Errorf("updated job = %+v, want progress 50 and an update time", updated)
+	}
+
+	if _, err := store.Get("missing"); err != ErrJobNotFound {
+		t.Errorf("Get(missing): err = %v, want %v", err, ErrJobNotFound)
+	}
+	if _, err := store.Update("missing", func(*models.Job) {}); err != ErrJobNotFound {
+		t.Errorf("Update(missing): err = %v, want %v", err, ErrJobNotFound)
+	}
+
+	now := time.Now()
+	past, future := now.Add(-time.Minute), now.Add(time.Minute)
+	store.Create(&models.Job{ID: "expired", ExpiresAt: &past})
+	store.Create(&models.Job{ID: "fresh", ExpiresAt: &future})
+	if removed := store.DeleteExpired(now); removed != 1 {
+		t.Errorf("DeleteExpired removed %d jobs, want 1", removed)
+	}
+	for id, want := range map[string]error{"a": nil, "expired": ErrJobNotFound, "fresh": nil} {
+		if _, err := store.Get(id); err != want {
+			t.Errorf("Get(%s): err = %v, want %v", id, err, want)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"ats-analyzer/models"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrJobNotFound is returned for unknown or expired job IDs
+var ErrJobNotFound = errors.New("job not found")
+
+// JobStore persists asynchronous jobs. Implementations must be safe for
+// concurrent use and return copies so callers cannot race with workers.
+type JobStore interface {
+	Create(job *models.Job) error
+	Get(id string) (*models.Job, error)
+	Update(id string, update func(job *models.Job)) (*models.Job, error)
+	DeleteExpired(now time.Time) int
+}
+
+// MemoryJobStore keeps jobs in process memory
+type MemoryJobStore struct {
+	mu   sync.RWMutex
+	jobs map[string]*models.Job
+}
+
+// NewMemoryJobStore creates an empty in-memory job store
+func NewMemoryJobStore() *MemoryJobStore {
+	return &MemoryJobStore{
+		jobs: make(map[string]*models.Job),
+	}
+}
+
+// Create stores a new job
+func (s *MemoryJobStore) Create(job *models.Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.jobs[job.ID]; exists {
+		return errors.New("job already exists: " + job.ID)
+	}
+	stored := *job
+	s.jobs[job.ID] = &stored
+	return nil
+}
+
+// Get returns a copy of the job with the given ID
+func (s *MemoryJobStore) Get(id string) (*models.Job, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	job, ok := s.jobs[id]
+	if !ok {
+		return nil, ErrJobNotFound
+	}
+	copied := *job
+	return &copied, nil
+}
+
+// Update applies update to the stored job and returns the updated copy
+func (s *MemoryJobStore) Update(id string, update func(job *models.Job)) (*models.Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[id]
+	if !ok {
+		return nil, ErrJobNotFound
+	}
+	update(job)
+	job.UpdatedAt = time.Now()
+	copied := *job
+	return &copied, nil
+}
+
+// DeleteExpired removes jobs whose results have expired and returns how many
+// were removed
+func (s *MemoryJobStore) DeleteExpired(now time.Time) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	removed := 0
+	for id, job := range s.jobs {
+		if job.ExpiresAt != nil && now.After(*job.ExpiresAt) {
+			delete(s.jobs, id)
+			removed++
+		}
+	}
+	return removed
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"ats-analyzer/models"
+	"math"
+	"sort"
+	"strings"
+)
+
+// minKeywordWeight is the weight, relative to a job description's top
+// keyword, below which a keyword is too incidental to report as missing
+const minKeywordWeight = 0.25
+
+// calculateKeywordMatch weighs the job description's skills and top
+// keywords and reports which the resume covers, most important first.
+// Required skills weigh 1 and preferred ones PreferredSkillWeight, raised
+// to their keyword weight when the description stresses them; keywords
+// weigh their BM25 weight. Skill matches come from skillMatch, which
+// understands aliases and implied skills; other keywords must appear in
+// the resume's text.
+func (s *Scorer) calculateKeywordMatch(resume *models.Resume, jobDesc *models.JobDescription, skillMatch models.SkillMatchResult) models.KeywordMatchResult {
+	keywordWeights := make(map[string]float64)
+	for _, keyword := range jobDesc.KeywordWeights {
+		keywordWeights[keyword.Term] = keyword.Weight
+	}
+
+	result := models.KeywordMatchResult{
+		Matched: []models.KeywordWeight{},
+		Missing: []models.KeywordWeight{},
+	}
+	covered := make(map[string]bool)
+	var matchedWeight, totalWeight float64
+	add := func(term string, weight float64, matched bool) {
+		keyword := models.KeywordWeight{Term: term, Weight: weight}
+		if matched {
+			result.Matched = append(result.Matched, keyword)
+			matchedWeight += weight
+		} else {
+			result.Missing = append(result.Missing, keyword)
+		}
+		totalWeight += weight
+	}
+
+	skills := []struct {
+		names  []string
+		weight float64
+	}{
+		{skillMatch.MatchedRequired, 1}, {skillMatch.MissingRequired, 1},
+		{skillMatch.MatchedPreferred, PreferredSkillWeight}, {skillMatch.MissingPreferred, PreferredSkillWeight},
+	}
+	for i, group := range skills {
+		for _, name := range group.names {
+			lower := strings.ToLower(name)
+			if covered[lower] {
+				continue
+			}
+			weight := math.Max(group.weight, keywordWeights[lower])
+			add(name, weight, i%2 == 0)
+
+			// A skill's words are not keywords of their own
+			covered[lower] = true
+			for _, word := range s.nlp.Tokenize(name) {
+				covered[word] = true
+			}
+		}
+	}
+
+	resumeTerms := make(map[string]bool)
+	for _, token := range s.nlp.Tokenize(resume.RawText) {
+		resumeTerms[token] = true
+	}
+	for _, keyword := range jobDesc.KeywordWeights {
+		if keyword.Weight < minKeywordWeight || covered[keyword.Term] {
+			continue
+		}
+		covered[keyword.Term] = true
+		add(keyword.Term, keyword.Weight, resumeTerms[keyword.Term])
+	}
+
+	// Stable, so equally weighted skills keep required before preferred
+	byWeight := func(keywords []models.KeywordWeight) {
+		sort.SliceStable(keywords, func(i, j int) bool {
+			return keywords[i].Weight > keywords[j].Weight
+		})
+	}
+	byWeight(result.Matched)
+	byWeight(result.Missing)
+
+	if totalWeight > 0 {
+		result.Percentage = matchedWeight / totalWeight * 100
+	}
+	return result
+}
+
+// keywordTerms returns the terms of weighted keywords in order
+func keywordTerms(keywords []models.KeywordWeight) []string {
+	terms := make([]string, len(keywords))
+	for i, keyword := range keywords {
+		terms[i] = keyword.Term
+	}
+	return terms
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"ats-analyzer/models"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// JobLibraryEnv names the environment variable pointing at a JSON file of
+// stored job descriptions
+const JobLibraryEnv = "JOB_LIBRARY_PATH"
+
+var (
+	defaultLibrary     *JobLibrary
+	defaultLibraryOnce sync.Once
+)
+
+// JobLibrary holds the stored job descriptions resumes can be matched against
+type JobLibrary struct {
+	Jobs []models.JobListing `json:"jobs"`
+	byID map[string]models.JobListing
+}
+
+// DefaultJobLibrary returns the process-wide job library loaded from
+// JOB_LIBRARY_PATH. The library is empty when the variable is not set.
+func DefaultJobLibrary() *JobLibrary {
+	defaultLibraryOnce.Do(func() {
+		if path := os.Getenv(JobLibraryEnv); path != "" {
+			library, err := LoadJobLibrary(path)
+			if err == nil {
+				defaultLibrary = library
+				return
+			}
+			logrus.Errorf("Failed to load job library from %s: %v", path, err)
+		}
+
+		defaultLibrary, _ = ParseJobLibrary([]byte(`{"jobs": []}`))
+	})
+
+	return defaultLibrary
+}
+
+// LoadJobLibrary reads a job library from a JSON file
+func LoadJobLibrary(filename string) (*JobLibrary, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return ParseJobLibrary(data)
+}
+
+// ParseJobLibrary decodes a job library from JSON and checks that every job
+// has a unique ID and a description
+func ParseJobLibrary(data []byte) (*JobLibrary, error) {
+	var library JobLibrary
+	if err := json.Unmarshal(data, &library); err != nil {
+		return nil, fmt.Errorf("failed to decode job library: %v", err)
+	}
+
+	library.byID = make(map[string]models.JobListing)
+	for _, job := range library.Jobs {
+		if job.ID == "" {
+			return nil, fmt.Errorf("job without an id")
+		}
+		if _, exists := library.byID[job.ID]; exists {
+			return nil, fmt.Errorf("duplicate job id: %s", job.ID)
+		}
+		if strings.TrimSpace(job.Description) == "" {
+			return nil, fmt.Errorf("job %s has no description", job.ID)
+		}
+		library.byID[job.ID] = job
+	}
+
+	return &library, nil
+}
+
+// Get returns the stored job with the given ID
+func (l *JobLibrary) Get(id string) (models.JobListing, error) {
+	job, ok := l.byID[id]
+	if !ok {
+		return models.JobListing{}, fmt.Errorf("unknown job id: %s", id)
+	}
+	return job, nil
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"ats-analyzer/models"
+	"sort"
+)
+
+// ReverseMatcher scores one resume against many job descriptions
+type ReverseMatcher struct {
+	parser *Parser
+	scorer *Scorer
+}
+
+// NewReverseMatcher creates a reverse matcher using the given scorer
+func NewReverseMatcher(scorer *Scorer) *ReverseMatcher {
+	return &ReverseMatcher{
+		parser: NewParser(),
+		scorer: scorer,
+	}
+}
+
+// Match scores an already parsed resume against every listing and returns
+// the roles sorted by score. Listings that fail to parse are reported
+// individually.
+func (m *ReverseMatcher) Match(resume *models.Resume, listings []models.JobListing, includeDetails bool) *models.ReverseMatchResult {
+	result := &models.ReverseMatchResult{
+		CandidateName: resume.PersonalInfo.Name,
+		TotalRoles:    len(listings),
+		Roles:         []models.RoleMatch{},
+		Failures:      []models.RoleFailure{},
+	}
+
+	for _, listing := range listings {
+		jobDesc, err := m.parser.ParseJobDescription(listing.Description)
+		if err != nil {
+			result.Failures = append(result.Failures, models.RoleFailure{
+				JobID: listing.ID,
+				Error: err.Error(),
+			})
+			continue
+		}
+
+		// A stored title is more reliable than the one guessed from the text
+		if listing.Title != "" {
+			jobDesc.Title = listing.Title
+		}
+
+		analysis := m.scorer.AnalyzeResume(resume, jobDesc)
+		role := roleMatch(listing.ID, jobDesc.Title, analysis)
+		if includeDetails {
+			role.Analysis = analysis
+		}
+		result.Roles = append(result.Roles, role)
+	}
+
+	sort.SliceStable(result.Roles, func(i, j int) bool {
+		return result.Roles[i].Score > result.Roles[j].Score
+	})
+	for i := range result.Roles {
+		result.Roles[i].Rank = i + 1
+	}
+
+	return result
+}
+
+// roleMatch summarises an analysis as the fit and gaps for one role
+func roleMatch(jobID, jobTitle string, analysis *models.AnalysisResult) models.RoleMatch {
+	unmet := []models.RequirementResult{}
+	for _, req := range analysis.ExperienceMatch.Requirements {
+		if !req.Met {
+			unmet = append(unmet, req)
+		}
+	}
+
+	return models.RoleMatch{
+		JobID:             jobID,
+		JobTitle:          jobTitle,
+		Score:             analysis.Score,
+		SkillMatch:        analysis.SkillMatch.Percentage,
+		TitleScore:        analysis.TitleMatch.Score * 100,
+		MatchedSkills:     append([]string{}, analysis.SkillMatch.MatchedSkills...),
+		MissingRequired:   append([]string{}, analysis.SkillMatch.MissingRequired...),
+		MissingPreferred:  append([]string{}, analysis.SkillMatch.MissingPreferred...),
+		YearsRequired:     analysis.ExperienceMatch.YearsRequired,
+		YearsCandidate:    analysis.ExperienceMatch.YearsCandidate,
+		MeetsExperience:   analysis.ExperienceMatch.MeetsRequirement,
+		UnmetRequirements: unmet,
+		ScoreBreakdown:    analysis.ScoreBreakdown,
+	}
+}
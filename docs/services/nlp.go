@@ -1,15 +1,22 @@
 package services
 
 import (
+	"ats-analyzer/models"
 	"math"
 	"sort"
 	"strings"
 	"unicode"
 )
 
+// maxKeywordDocShare is the share of corpus documents beyond which a term
+// other than a skill is too generic to be a keyword
+const maxKeywordDocShare = 0.1
+
 // NLPService provides natural language processing capabilities
 type NLPService struct {
 	stopWords map[string]bool
+	corpus    *KeywordCorpus
+	taxonomy  *SkillTaxonomy
 }
 
 // NewNLPService creates a new NLP service instance
@@ -22,10 +29,18 @@ func NewNLPService() *NLPService {
 		"with": true, "you": true, "your": true, "have": true, "had": true, "this": true,
 		"they": true, "we": true, "our": true, "us": true, "can": true, "could": true,
 		"would": true, "should": true, "may": true, "might": true, "must": true,
+		"been": true, "being": true, "also": true, "about": true, "who": true,
+		"what": true, "which": true, "when": true, "where": true, "how": true,
+		"their": true, "them": true, "there": true, "these": true, "those": true,
+		"into": true, "than": true, "then": true, "not": true, "but": true,
+		"all": true, "any": true, "each": true, "more": true, "most": true,
+		"such": true, "very": true, "just": true, "while": true, "other": true,
 	}
 
 	return &NLPService{
 		stopWords: stopWords,
+		corpus:    DefaultKeywordCorpus(),
+		taxonomy:  DefaultSkillTaxonomy(),
 	}
 }
 
@@ -117,40 +132,97 @@ func (nlp *NLPService) CalculateTFIDF(documents []string) map[string]float64 {
 	return tfidfScores
 }
 
-// ExtractKeywords extracts top keywords from text using TF-IDF
+// ExtractKeywords extracts the top keywords from text, ranked by BM25
+// against the keyword corpus
 func (nlp *NLPService) ExtractKeywords(text string, topK int) []string {
-	// For single document, we'll use term frequency as a simple approach
-	tokens := nlp.Tokenize(text)
-	termFreq := make(map[string]int)
-
-	for _, token := range tokens {
-		termFreq[token]++
+	var keywords []string
+	for _, keyword := range nlp.RankKeywords(text, topK) {
+		keywords = append(keywords, keyword.Term)
 	}
+	return keywords
+}
 
-	// Sort by frequency
-	type termScore struct {
-		term  string
-		score int
+// RankKeywords returns the topK keywords of text with their weights. Terms
+// are weighted by BM25 against the keyword corpus, so words most documents
+// use, such as "experience" or "team", rank below the ones that set this
+// document apart. Skills from the taxonomy rank ahead of every other term.
+// Other terms more than maxKeywordDocShare of documents use are left out, as
+// are those no other document uses: a word the corpus has not seen would be
+// weighted as rare whether it is jargon, a name or filler.
+func (nlp *NLPService) RankKeywords(text string, topK int) []models.KeywordWeight {
+	scores := nlp.corpus.BM25(nlp.keywordTokens(text))
+	counted := 0
+	if nlp.corpus.Contains(text) {
+		counted = 1
+	}
+	generic := int(float64(nlp.corpus.Documents()-counted) * maxKeywordDocShare)
+	topOther := 0.0
+	for term, score := range scores {
+		if nlp.isSkillTerm(term) {
+			continue
+		}
+		if others := nlp.corpus.DocFreq(term) - counted; others <= 0 || others > generic {
+			delete(scores, term)
+		} else if score > topOther {
+			topOther = score
+		}
 	}
 
-	var scores []termScore
-	for term, freq := range termFreq {
-		scores = append(scores, termScore{term, freq})
+	var keywords []models.KeywordWeight
+	for term, score := range scores {
+		if nlp.isSkillTerm(term) {
+			score += topOther
+		}
+		keywords = append(keywords, models.KeywordWeight{Term: term, Weight: score})
 	}
 
-	sort.Slice(scores, func(i, j int) bool {
-		return scores[i].score > scores[j].score
+	// Ties go alphabetically so the ranking is stable
+	sort.Slice(keywords, func(i, j int) bool {
+		if keywords[i].Weight != keywords[j].Weight {
+			return keywords[i].Weight > keywords[j].Weight
+		}
+		return keywords[i].Term < keywords[j].Term
 	})
+	if len(keywords) > topK {
+		keywords = keywords[:topK]
+	}
 
-	// Return top K terms
-	var keywords []string
-	for i := 0; i < topK && i < len(scores); i++ {
-		keywords = append(keywords, scores[i].term)
+	// Weights are relative to the top keyword
+	if len(keywords) > 0 && keywords[0].Weight > 0 {
+		top := keywords[0].Weight
+		for i := range keywords {
+			keywords[i].Weight = math.Round(keywords[i].Weight/top*1000) / 1000
+		}
 	}
 
 	return keywords
 }
 
+// isSkillTerm reports whether a keyword names a skill in the taxonomy. Terms
+// that are also ordinary words, such as "swift", do not count.
+func (nlp *NLPService) isSkillTerm(term string) bool {
+	_, ok := nlp.taxonomy.Canonical(term)
+	return ok && !nlp.taxonomy.ambiguous[term]
+}
+
+// AddToCorpus counts a job description in the keyword corpus. Resumes are
+// never added, as their names and addresses would be kept with the counts.
+func (nlp *NLPService) AddToCorpus(text string) {
+	nlp.corpus.Add(text, nlp.keywordTokens(text))
+}
+
+// keywordTokens returns the tokens of text that can be keywords. Years and
+// other figures cannot.
+func (nlp *NLPService) keywordTokens(text string) []string {
+	var tokens []string
+	for _, token := range nlp.Tokenize(text) {
+		if strings.IndexFunc(token, unicode.IsLetter) >= 0 {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
+
 // CalculateCosineSimilarity calculates cosine similarity between two texts
 func (nlp *NLPService) CalculateCosineSimilarity(text1, text2 string) float64 {
 	tokens1 := nlp.Tokenize(text1)
@@ -0,0 +1,219 @@
+package services
+
+import (
+	"archive/zip"
+	"ats-analyzer/models"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// OpenDocument namespaces used in content.xml
+const (
+	odtTextNS   = "urn:oasis:names:tc:opendocument:xmlns:text:1.0"
+	odtTableNS  = "urn:oasis:names:tc:opendocument:xmlns:table:1.0"
+	odtOfficeNS = "urn:oasis:names:tc:opendocument:xmlns:office:1.0"
+)
+
+// odtMIMEType is stored uncompressed at the start of every OpenDocument text
+// file, so it shows in the first bytes
+const odtMIMEType = "mimetypeapplication/vnd.oasis.opendocument.text"
+
+var odtPageCountRegex = regexp.MustCompile(`meta:page-count="(\d+)"`)
+
+// odtExtractor reads OpenDocument text files. Paragraphs and headings become
+// blocks, list items keep their depth and table rows are flattened to one
+// block per row.
+type odtExtractor struct{}
+
+// Format describes OpenDocument text
+func (odtExtractor) Format() models.DocumentFormat {
+	return models.DocumentFormat{
+		Name:       "ODT",
+		Extensions: []string{".odt"},
+		MIMETypes:  []string{"application/vnd.oasis.opendocument.text"},
+	}
+}
+
+// Detect matches the extension or the archive's mimetype entry
+func (e odtExtractor) Detect(filename string, head []byte) bool {
+	return bytes.HasPrefix(head, zipMagic) && bytes.Contains(head, []byte(odtMIMEType)) ||
+		HasExtension(filename, e.Format().Extensions...)
+}
+
+// Validate checks the archive's mimetype entry and size, and the page count
+// stored in meta.xml when present
+func (odtExtractor) Validate(r io.ReaderAt, size int64, limits UploadLimits) error {
+	reader, err := checkArchive(r, size, "ODT", limits)
+	if err != nil {
+		return err
+	}
+
+	mimeType, err := readArchiveEntry(reader, "mimetype", maxArchiveMetadataSize)
+	if err != nil {
+		return archiveReadError("ODT mimetype", err)
+	}
+	if "mimetype"+strings.TrimSpace(string(mimeType)) != odtMIMEType {
+		return uploadError(CodeFormatMismatch, "file is not an ODT document")
+	}
+
+	meta, err := readArchiveEntry(reader, "meta.xml", maxArchiveMetadataSize)
+	if err != nil {
+		return archiveReadError("ODT metadata", err)
+	}
+	if match := odtPageCountRegex.FindSubmatch(meta); match != nil {
+		pages, _ := strconv.Atoi(string(match[1]))
+		return checkPageCount(pages, limits)
+	}
+	return nil
+}
+
+// Extract reads content.xml from the archive
+func (odtExtractor) Extract(r io.ReaderAt, size int64) ([]models.TextBlock, error) {
+	reader, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range reader.File {
+		if file.Name != "content.xml" {
+			continue
+		}
+
+		content, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer content.Close()
+
+		return odtToBlocks(content)
+	}
+
+	return nil, fmt.Errorf("content.xml not found")
+}
+
+// odtToBlocks walks content.xml and collects its visible text
+func odtToBlocks(r io.Reader) ([]models.TextBlock, error) {
+	decoder := xml.NewDecoder(r)
+	var w blockWriter
+	listDepth := 0
+	skipDepth := 0
+	cellIndex := 0
+	inItem := false
+	// Whitespace between elements outside paragraphs is not content
+	paragraphDepth := 0
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid content.xml: %v", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if skipDepth > 0 {
+				skipDepth++
+				continue
+			}
+
+			switch {
+			case t.Name.Space == odtOfficeNS && t.Name.Local == "annotation",
+				t.Name.Space == odtTextNS && (t.Name.Local == "note-citation" || t.Name.Local == "tracked-changes"):
+				// Comments, note markers and change history are not resume text
+				skipDepth = 1
+			case t.Name.Space == odtTextNS && t.Name.Local == "h":
+				w.start(models.BlockHeading, odtOutlineLevel(t))
+				paragraphDepth++
+			case t.Name.Space == odtTextNS && t.Name.Local == "p":
+				paragraphDepth++
+				// Paragraphs inside a cell continue the row
+				if cellIndex == 0 {
+					if inItem {
+						// The item's first paragraph carries its bullet
+						w.start(models.BlockListItem, listDepth)
+						inItem = false
+					} else {
+						w.start(models.BlockParagraph, 0)
+					}
+				} else if !w.empty() && !w.hasSuffix(" ") {
+					w.write(" ")
+				}
+			case t.Name.Space == odtTextNS && t.Name.Local == "list":
+				listDepth++
+			case t.Name.Space == odtTextNS && t.Name.Local == "list-item":
+				inItem = true
+			case t.Name.Space == odtTextNS && t.Name.Local == "s":
+				w.write(strings.Repeat(" ", odtSpaceCount(t)))
+			case t.Name.Space == odtTextNS && t.Name.Local == "tab":
+				w.write("\t")
+			case t.Name.Space == odtTextNS && t.Name.Local == "line-break":
+				w.write("\n")
+			case t.Name.Space == odtTableNS && t.Name.Local == "table-row":
+				w.start(models.BlockTableRow, 0)
+				cellIndex = 0
+			case t.Name.Space == odtTableNS && t.Name.Local == "table-cell":
+				if cellIndex > 0 {
+					w.write("  ")
+				}
+				cellIndex++
+			}
+
+		case xml.EndElement:
+			if skipDepth > 0 {
+				skipDepth--
+				continue
+			}
+
+			switch {
+			case t.Name.Space == odtTextNS && t.Name.Local == "list":
+				listDepth--
+			case t.Name.Space == odtTextNS && (t.Name.Local == "p" || t.Name.Local == "h"):
+				paragraphDepth--
+				if cellIndex == 0 {
+					w.end()
+				}
+			case t.Name.Space == odtTableNS && t.Name.Local == "table-row":
+				w.end()
+				cellIndex = 0
+			}
+
+		case xml.CharData:
+			if skipDepth == 0 && paragraphDepth > 0 {
+				w.write(string(t))
+			}
+		}
+	}
+
+	return w.finish(), nil
+}
+
+// odtOutlineLevel returns a heading's level, defaulting to 1
+func odtOutlineLevel(element xml.StartElement) int {
+	for _, attr := range element.Attr {
+		if attr.Name.Local == "outline-level" {
+			if level, err := strconv.Atoi(attr.Value); err == nil && level > 0 {
+				return level
+			}
+		}
+	}
+	return 1
+}
+
+// odtSpaceCount returns how many spaces a text:s element stands for
+func odtSpaceCount(element xml.StartElement) int {
+	for _, attr := range element.Attr {
+		if attr.Name.Local == "c" {
+			if count, err := strconv.Atoi(attr.Value); err == nil && count > 0 {
+				return count
+			}
+		}
+	}
+	return 1
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"archive/zip"
+	"ats-analyzer/models"
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// buildZip writes an archive holding the given name and content pairs, in
+// order. The mimetype entry is stored uncompressed as OpenDocument requires.
+func buildZip(t *testing.T, entries ...string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for i := 0; i+1 < len(entries); i += 2 {
+		header := &zip.FileHeader{Name: entries[i], Method: zip.Deflate}
+		if entries[i] == "mimetype" {
+			header.Method = zip.Store
+		}
+		w, err := writer.CreateHeader(header)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(entries[i+1])); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// odtContent wraps body markup in an OpenDocument content.xml
+func odtContent(body string) string {
+	return `<?xml version="1.0" encoding="UTF-8"?>
+<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0"
+  xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0"
+  xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0"
+  xmlns:dc="http://purl.org/dc/elements/1.1/">
+<office:body><office:text>` + body + `</office:text></office:body></office:document-content>`
+}
+
+func TestODTExtractor(t *testing.T) {
+	content := odtContent(`
+<text:tracked-changes><text:changed-region><text:deletion><text:p>Deleted line</text:p></text:deletion></text:changed-region></text:tracked-changes>
+<text:h text:outline-level="1">Jane Doe</text:h>
+<text:p>jane@example.com<text:tab/>London<text:line-break/>Go<text:s text:c="3"/>Rust</text:p>
+<text:h text:outline-level="2">Experience<office:annotation><dc:creator>Reviewer</dc:creator><text:p>Fix this</text:p></office:annotation></text:h>
+<text:list>
+  <text:list-item><text:p>Built services<text:note><text:note-citation>1</text:note-citation></text:note></text:p>
+    <text:list><text:list-item><text:p>Cut latency</text:p></text:list-item></text:list>
+  </text:list-item>
+</text:list>
+<table:table>
+  <table:table-row><table:table-cell><text:p>Skill</text:p></table:table-cell><table:table-cell><text:p>Years</text:p></table:table-cell></table:table-row>
+  <table:table-row><table:table-cell><text:p>Go</text:p><text:p>Rust</text:p></table:table-cell><table:table-cell><text:p>5</text:p></table:table-cell></table:table-row>
+</table:table>
+<text:h>Education</text:h>`)
+	data := buildZip(t, "mimetype", "application/vnd.oasis.opendocument.text", "content.xml", content)
+
+	blocks, err := extractBytes(odtExtractor{}, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []models.TextBlock{
+		{Kind: models.BlockHeading, Text: "Jane Doe", Level: 1},
+		{Kind: models.BlockParagraph, Text: "jane@example.com\tLondon\nGo   Rust"},
+		{Kind: models.BlockHeading, Text: "Experience", Level: 2},
+		{Kind: models.BlockListItem, Text: "Built services", Level: 1},
+		{Kind: models.BlockListItem, Text: "Cut latency", Level: 2},
+		{Kind: models.BlockTableRow, Text: "Skill  Years"},
+		{Kind: models.BlockTableRow, Text: "Go Rust  5"},
+		{Kind: models.BlockHeading, Text: "Education", Level: 1},
+	}
+	if !reflect.DeepEqual(blocks, want) {
+		t.Errorf("got  %+v\nwant %+v", blocks, want)
+	}
+}
+
+func TestODTExtractorRejectsCorruptFiles(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  []byte
+		error string
+	}{
+		{"not an archive", []byte("Jane Doe, Go developer"), "zip: not a valid zip file"},
+		{"no content", buildZip(t, "mimetype", "application/vnd.oasis.opendocument.text"), "content.xml not found"},
+		{"malformed content", buildZip(t, "content.xml", odtContent("<text:p>Jane</text:h>")), "invalid content.xml"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := extractBytes(odtExtractor{}, tt.data)
+			if err == nil || !strings.Contains(err.Error(), tt.error) {
+				t.Errorf("err = %v, want %q", err, tt.error)
+			}
+		})
+	}
+}
@@ -4,59 +4,118 @@ import (
         "ats-analyzer/models"
         "ats-analyzer/utils"
         "fmt"
-        "path/filepath"
+        "io"
+        "os"
         "regexp"
         "strconv"
         "strings"
-        "time"
+)
+
+var (
+        internshipRegex = regexp.MustCompile(`(?i)\b(intern|internship|co-op|trainee|apprentice)\b`)
+        partTimeRegex   = regexp.MustCompile(`(?i)\bpart[\s-]?time\b`)
+        contractRegex   = regexp.MustCompile(`(?i)\b(contract|contractor|freelance|freelancer)\b`)
+)
 
-        "github.com/ledongthuc/pdf"
-        "github.com/unidoc/unioffice/document"
+var (
+        jdYearsRegex               = regexp.MustCompile(`(?i)(\d+)\s*\+?\s*(?:-\s*\d+\s*)?(?:\+\s*)?(?:years?|yrs?)\b`)
+        jdDomainNoiseRegex         = regexp.MustCompile(`\b(of|in|with|using|on|the|a|an|professional|relevant|hands-on|proven|commercial|industry|practical|working|related|minimum|at least|experience|experienced|environments?|settings?|roles?|positions?|field)\b`)
+        jdTrailingConjunctionRegex = regexp.MustCompile(`(?i)[\s,]+(and|or|plus|as well as)?[\s,]*$`)
+        jdExperienceWordRegex      = regexp.MustCompile(`(?i)\b(experience|experienced)\b`)
+        jdCompanyContextRegex      = regexp.MustCompile(`(?i)\b(we|we've|we're|our|us|company|business|founded|established|history)\b`)
+        jdNotExperienceRegex       = regexp.MustCompile(`(?i)\b(tenure|anniversary|in business|vacation|pto|paid leave|holidays?|vesting|vests?|warranty|guarantee|ago|old)\b`)
 )
 
+// experienceDomainWords are the words that make the phrase after "N years"
+// in a requirements block a field of work, such as "5 years in cloud"
+var experienceDomainWords = map[string]bool{
+        "software": true, "development": true, "engineering": true, "programming": true,
+        "coding": true, "cloud": true, "backend": true, "back-end": true, "frontend": true,
+        "front-end": true, "web": true, "mobile": true, "data": true, "analytics": true,
+        "machine": true, "learning": true, "devops": true, "infrastructure": true,
+        "security": true, "networking": true, "testing": true, "qa": true, "design": true,
+        "product": true, "project": true, "management": true, "managing": true,
+        "leadership": true, "leading": true, "sales": true, "marketing": true,
+        "finance": true, "accounting": true, "consulting": true, "operations": true,
+        "research": true, "support": true, "administration": true, "architecture": true,
+        "systems": true, "teaching": true, "nursing": true, "clinical": true,
+        "recruiting": true, "hr": true, "legal": true, "it": true,
+}
+
 // Parser handles document parsing
 type Parser struct {
-        nlp *NLPService
+        nlp        *NLPService
+        skills     *SkillMatcher
+        extractors *ExtractorRegistry
 }
 
 // NewParser creates a new parser instance
 func NewParser() *Parser {
         return &Parser{
-                nlp: NewNLPService(),
+                nlp:        NewNLPService(),
+                skills:     NewSkillMatcher(DefaultSkillTaxonomy()),
+                extractors: DefaultExtractors(),
         }
 }
 
 // ParseResume parses a resume file and extracts structured data
 func (p *Parser) ParseResume(filename string) (*models.Resume, error) {
-        ext := strings.ToLower(filepath.Ext(filename))
-        var text string
-        var err error
-
-        switch ext {
-        case ".pdf":
-                text, err = p.parsePDF(filename)
-        case ".docx":
-                text, err = p.parseDOCX(filename)
-        default:
-                return nil, fmt.Errorf("unsupported file format: %s", ext)
+        file, err := os.Open(filename)
+        if err != nil {
+                return nil, err
         }
+        defer file.Close()
+
+        info, err := file.Stat()
+        if err != nil {
+                return nil, err
+        }
+
+        return p.ParseResumeFrom(filename, file, info.Size(), nil)
+}
 
+// ParseResumeFrom parses a resume held in r, such as an in-memory upload.
+// name is the uploaded file name, used to recognise formats that cannot be
+// told apart by content. Each completed stage is reported with its partial
+// result to onStage.
+func (p *Parser) ParseResumeFrom(name string, r io.ReaderAt, size int64, onStage StageFunc) (*models.Resume, error) {
+        extractor, err := p.extractors.Detect(name, r, size)
         if err != nil {
                 return nil, fmt.Errorf("failed to extract text: %v", err)
         }
+        blocks, err := extractor.Extract(r, size)
+        if err != nil {
+                if ErrorCode(err) != "" {
+                        return nil, err
+                }
+                return nil, fmt.Errorf("failed to extract text: %v", err)
+        }
+        text := BlocksToText(blocks)
+
+        // Text too sparse to analyse is reported rather than scored
+        inspected := inspectFormat(extractor, r, size)
+        if err := checkReadable(text, inspected); err != nil {
+                return nil, err
+        }
 
         resume := &models.Resume{
                 RawText: text,
         }
+        onStage.Emit(StageTextExtracted, textStats(text))
+
+        // Split into sections so each extractor only sees its own block
+        resume.Sections = p.segmentSections(text)
+        onStage.Emit(StageSectionsDetected, sectionNames(resume))
 
         // Extract structured data from text
         p.extractPersonalInfo(resume, text)
-        p.extractEducation(resume, text)
-        p.extractExperience(resume, text)
+        p.extractEducation(resume, p.sectionText(resume, models.SectionEducation, text))
+        p.extractExperience(resume, p.sectionText(resume, models.SectionExperience, text))
         p.extractSkills(resume, text)
-        p.extractProjects(resume, text)
-        p.extractCertifications(resume, text)
-        p.analyzeFormat(resume, text)
+        p.extractProjects(resume, p.sectionText(resume, models.SectionProjects, text))
+        p.extractCertifications(resume, p.sectionText(resume, models.SectionCertifications, text))
+        p.analyzeFormat(resume, blocks, inspected)
+        onStage.Emit(StageResumeParsed, resumeSummary(resume))
 
         return resume, nil
 }
@@ -66,6 +125,8 @@ func (p *Parser) ParseJobDescription(text string) (*models.JobDescription, error
         jd := &models.JobDescription{
                 RawText: text,
         }
+        // Counted before its keywords are ranked against the corpus
+        p.nlp.AddToCorpus(text)
 
         p.extractJDTitle(jd, text)
         p.extractJDCompany(jd, text)
@@ -78,69 +139,14 @@ func (p *Parser) ParseJobDescription(text string) (*models.JobDescription, error
         return jd, nil
 }
 
-// parsePDF extracts text from PDF file
-func (p *Parser) parsePDF(filename string) (string, error) {
-        file, reader, err := pdf.Open(filename)
-        if err != nil {
-                return "", err
-        }
-        defer file.Close()
-
-        var text strings.Builder
-        totalPages := reader.NumPage()
-
-        for i := 1; i <= totalPages; i++ {
-                page := reader.Page(i)
-                if page.V.IsNull() {
-                        continue
-                }
-
-                pageText, err := page.GetPlainText(nil)
-                if err != nil {
-                        continue
-                }
-                text.WriteString(pageText)
-                text.WriteString("\n")
-        }
-
-        return text.String(), nil
-}
-
-// parseDOCX extracts text from DOCX file
-func (p *Parser) parseDOCX(filename string) (string, error) {
-        doc, err := document.Open(filename)
-        if err != nil {
-                return "", err
-        }
-        defer doc.Close()
-
-        var text strings.Builder
-        for _, para := range doc.Paragraphs() {
-                for _, run := range para.Runs() {
-                        text.WriteString(run.Text())
-                }
-                text.WriteString("\n")
-        }
-
-        return text.String(), nil
-}
-
 // extractPersonalInfo extracts personal information from resume text
 func (p *Parser) extractPersonalInfo(resume *models.Resume, text string) {
         lines := strings.Split(text, "\n")
         
-        // Email regex
-        emailRegex := regexp.MustCompile(`[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}`)
-        if email := emailRegex.FindString(text); email != "" {
+        if email := emailAddressRegex.FindString(text); email != "" {
                 resume.PersonalInfo.Email = email
         }
 
-        // Phone regex
-        phoneRegex := regexp.MustCompile(`(\+?1?[-.\s]?)?\(?([0-9]{3})\)?[-.\s]?([0-9]{3})[-.\s]?([0-9]{4})`)
-        if phone := phoneRegex.FindString(text); phone != "" {
-                resume.PersonalInfo.Phone = phone
-        }
-
         // Name is typically in the first few lines
         for i, line := range lines {
                 if i > 5 { // Don't look beyond first few lines
@@ -149,7 +155,7 @@ func (p *Parser) extractPersonalInfo(resume *models.Resume, text string) {
                 cleanLine := strings.TrimSpace(line)
                 if len(cleanLine) > 2 && len(cleanLine) < 50 && 
                    !strings.Contains(cleanLine, "@") && 
-                   !phoneRegex.MatchString(cleanLine) {
+                   !phoneCandidateRegex.MatchString(cleanLine) {
                         // Simple name detection - could be improved
                         if regexp.MustCompile(`^[A-Za-z\s.]{2,}$`).MatchString(cleanLine) {
                                 resume.PersonalInfo.Name = cleanLine
@@ -157,6 +163,26 @@ func (p *Parser) extractPersonalInfo(resume *models.Resume, text string) {
                         }
                 }
         }
+
+        // Websites and location are only trusted from the contact lines at
+        // the top, which is the whole text when no headings were found
+        header := resume.Sections[models.SectionHeader]
+        if !isSegmented(resume) && len(lines) > 10 {
+                header = strings.Join(lines[:10], "\n")
+        }
+
+        resume.PersonalInfo.Links = extractProfileLinks(text, header)
+        if address, location, ok := extractLocation(header); ok {
+                resume.PersonalInfo.Address = address
+                resume.PersonalInfo.Location = location
+        }
+
+        // The location decides the country of numbers written nationally
+        if phone, ok := extractPhone(text, header, resume.PersonalInfo.Location.Country); ok {
+                resume.PersonalInfo.Phone = phone.Raw
+                resume.PersonalInfo.PhoneE164 = phone.E164
+                resume.PersonalInfo.PhoneCountry = phone.Region
+        }
 }
 
 // extractEducation extracts education information
@@ -200,107 +226,223 @@ func (p *Parser) extractEducation(resume *models.Resume, text string) {
 
 // extractExperience extracts work experience
 func (p *Parser) extractExperience(resume *models.Resume, text string) {
-        // Simple experience extraction - look for date patterns and company names
-        dateRegex := regexp.MustCompile(`(?i)(jan|feb|mar|apr|may|jun|jul|aug|sep|oct|nov|dec)[a-z]*\s+(19|20)\d{2}`)
+        // Each line holding a date range is an entry. Its position and company
+        // are any text beside the dates, then the title lines directly above
+        // and failing those the lines just below; its description runs to the
+        // next entry's header.
         lines := strings.Split(text, "\n")
-        
+
+        type datedEntry struct {
+                experience models.Experience
+                headers    []string
+                header     int // first line of the header
+                body       int // first line of the description
+                dateLine   string
+        }
+        var entries []datedEntry
+
         for i, line := range lines {
-                if dateRegex.MatchString(line) {
-                        experience := models.Experience{}
-                        
-                        // Try to extract dates
-                        dates := dateRegex.FindAllString(line, -1)
-                        if len(dates) > 0 {
-                                startDate, err := p.parseDate(dates[0])
-                                if err == nil {
-                                        experience.StartDate = startDate
-                                }
-                                
-                                if len(dates) > 1 {
-                                        endDate, err := p.parseDate(dates[1])
-                                        if err == nil {
-                                                experience.EndDate = &endDate
-                                        }
+                dateRange, ok := ParseDateRange(line)
+                if !ok {
+                        continue
+                }
+                entry := datedEntry{
+                        experience: models.Experience{
+                                StartDate:     dateRange.Start,
+                                EndDate:       dateRange.End,
+                                IsCurrent:     dateRange.IsCurrent,
+                                DatePrecision: dateRange.Precision,
+                        },
+                        header:   i,
+                        body:     i + 1,
+                        dateLine: line,
+                }
+
+                // Text beside the dates, e.g. "Acme Corp | Jan 2020 - Present"
+                entry.headers = headerParts(strings.Replace(line, dateRange.Raw, "", 1))
+
+                // Title lines above, up to the previous entry
+                floor := 0
+                if len(entries) > 0 {
+                        floor = entries[len(entries)-1].body
+                }
+                above := 0
+                for j := i - 1; j >= floor && len(entry.headers) < 2; j-- {
+                        if strings.TrimSpace(lines[j]) == "" {
+                                if above > 0 {
+                                        break
                                 }
+                                continue
                         }
-                        
-                        // Look for company and position in surrounding lines
-                        startIdx := i - 2
-                        if startIdx < 0 {
-                                startIdx = 0
+                        header, ok := experienceHeader(lines[j])
+                        if !ok {
+                                break
                         }
-                        endIdx := i + 3
-                        if endIdx > len(lines) {
-                                endIdx = len(lines)
+                        entry.headers = append(headerParts(header), entry.headers...)
+                        entry.header = j
+                        above++
+                }
+
+                // Lines below complete the header: a title, or anything when
+                // the dates open the entry
+                for j := i + 1; j < len(lines) && len(entry.headers) < 2; j++ {
+                        if strings.TrimSpace(lines[j]) == "" {
+                                continue
                         }
-                        for j := startIdx; j < endIdx; j++ {
-                                currentLine := strings.TrimSpace(lines[j])
-                                if len(currentLine) > 2 && !dateRegex.MatchString(currentLine) {
-                                        if experience.Company == "" {
-                                                experience.Company = currentLine
-                                        } else if experience.Position == "" {
-                                                experience.Position = currentLine
-                                        }
-                                }
+                        header, ok := experienceHeader(lines[j])
+                        if !ok || (above > 0 && !isPositionTitle(header)) {
+                                break
                         }
-                        
-                        if experience.Company != "" {
-                                resume.Experience = append(resume.Experience, experience)
+                        entry.headers = append(entry.headers, headerParts(header)...)
+                        entry.body = j + 1
+                }
+
+                entries = append(entries, entry)
+        }
+
+        for k, entry := range entries {
+                experience := entry.experience
+                experience.Position, experience.Company = splitExperienceHeader(entry.headers)
+
+                end := len(lines)
+                if k+1 < len(entries) {
+                        end = entries[k+1].header
+                }
+                var description []string
+                for _, descLine := range lines[entry.body:end] {
+                        if descLine = strings.TrimSpace(descLine); descLine != "" {
+                                description = append(description, descLine)
                         }
                 }
+                experience.Description = strings.Join(description, "\n")
+                experience.EmploymentType = detectEmploymentType(experience.Position + "\n" + experience.Company + "\n" + entry.dateLine)
+
+                if experience.Company != "" || experience.Position != "" {
+                        resume.Experience = append(resume.Experience, experience)
+                }
         }
 }
 
-// extractSkills extracts skills from resume text
-func (p *Parser) extractSkills(resume *models.Resume, text string) {
-        // Common technical skills - this could be expanded with a larger dictionary
-        skillKeywords := []string{
-                "python", "java", "javascript", "typescript", "go", "golang", "rust", "c++", "c#",
-                "react", "angular", "vue", "nodejs", "express", "django", "flask", "spring",
-                "sql", "mysql", "postgresql", "mongodb", "redis", "elasticsearch",
-                "aws", "azure", "gcp", "docker", "kubernetes", "terraform", "ansible",
-                "git", "github", "gitlab", "jenkins", "ci/cd", "devops",
-                "machine learning", "deep learning", "tensorflow", "pytorch", "scikit-learn",
-                "html", "css", "bootstrap", "tailwind", "sass", "less",
+// headerSeparatorRegex splits a position from a company on one line
+var headerSeparatorRegex = regexp.MustCompile(`\s*[|•·]\s*|\s+[-–—@]\s+|\s+at\s+`)
+
+// headerParts splits a header line into the position and company it names,
+// e.g. "Data Analyst at Acme" or "Data Analyst, Acme"
+func headerParts(line string) []string {
+        var parts []string
+        for _, part := range headerSeparatorRegex.Split(line, -1) {
+                part = strings.TrimSpace(strings.Trim(part, " \t|,-–—()"))
+                if comma := strings.Index(part, ","); comma > 0 && isPositionTitle(part[:comma]) {
+                        parts = append(parts, strings.TrimSpace(part[:comma]))
+                        part = strings.TrimSpace(strings.Trim(part[comma+1:], " \t,"))
+                }
+                if len(part) > 2 {
+                        parts = append(parts, part)
+                }
         }
-        
-        textLower := strings.ToLower(text)
-        var foundSkills []string
-        
-        for _, skill := range skillKeywords {
-                if strings.Contains(textLower, strings.ToLower(skill)) {
-                        foundSkills = append(foundSkills, skill)
+        return parts
+}
+
+// experienceHeader returns a line that can name a position or company:
+// short, not a bullet, a sentence or a labelled detail
+func experienceHeader(line string) (string, bool) {
+        header := strings.TrimSpace(line)
+        if entryBulletRegex.MatchString(line) || !isEntryTitle(header) {
+                return "", false
+        }
+        if _, isDate := ParseDateRange(header); isDate {
+                return "", false
+        }
+        return header, true
+}
+
+// isPositionTitle reports whether a header names a role rather than an
+// employer
+func isPositionTitle(header string) bool {
+        normalized, _ := normalizeTitle(header)
+        return isRecognizedTitle(normalized) || internshipRegex.MatchString(header)
+}
+
+// splitExperienceHeader picks the position and company from an entry's
+// header lines. The first that reads as a job title is the position and the
+// first other line the company; with no recognisable title, the lines are
+// taken as company then position.
+func splitExperienceHeader(headers []string) (position, company string) {
+        var others []string
+        for _, header := range headers {
+                if position == "" && isPositionTitle(header) {
+                        position = header
+                } else {
+                        others = append(others, header)
                 }
         }
-        
-        resume.Skills = utils.RemoveDuplicates(foundSkills)
+        if len(others) > 0 {
+                company = others[0]
+        }
+        if position == "" && len(others) > 1 {
+                position = others[1]
+        }
+        return position, company
+}
+
+// detectEmploymentType classifies a role from its title and heading text
+func detectEmploymentType(text string) string {
+        switch {
+        case internshipRegex.MatchString(text):
+                return models.EmploymentInternship
+        case partTimeRegex.MatchString(text):
+                return models.EmploymentPartTime
+        case contractRegex.MatchString(text):
+                return models.EmploymentContract
+        default:
+                return models.EmploymentFullTime
+        }
+}
+
+// extractSkills extracts skills from resume text, leaving out the contact
+// links and email addresses extractPersonalInfo found
+func (p *Parser) extractSkills(resume *models.Resume, text string) {
+        resume.Skills = p.skills.FindSkills(stripLinks(text, resume.PersonalInfo.Links))
 }
 
 // extractProjects extracts project information
 func (p *Parser) extractProjects(resume *models.Resume, text string) {
-        projectRegex := regexp.MustCompile(`(?i)(project|projects?)[\s:]*`)
-        lines := strings.Split(text, "\n")
-        
-        for i, line := range lines {
-                if projectRegex.MatchString(line) {
-                        // Extract projects from next few lines
-                        for j := i + 1; j < len(lines) && j < i + 10; j++ {
-                                projectLine := strings.TrimSpace(lines[j])
-                                if len(projectLine) > 10 {
-                                        project := models.Project{
-                                                Name:        projectLine,
-                                                Description: projectLine,
-                                        }
-                                        resume.Projects = append(resume.Projects, project)
+        // Text is already the projects section
+        if !isSegmented(resume) {
+                lines := strings.Split(text, "\n")
+                text = ""
+                projectRegex := regexp.MustCompile(`(?i)(project|projects?)[\s:]*`)
+                for i, line := range lines {
+                        if projectRegex.MatchString(line) {
+                                // Projects are in the next few lines
+                                end := i + 10
+                                if end > len(lines) {
+                                        end = len(lines)
                                 }
+                                text = strings.Join(lines[i+1:end], "\n")
+                                break
                         }
-                        break
                 }
         }
+
+        for _, entry := range groupEntries(text) {
+                resume.Projects = append(resume.Projects, models.Project{
+                        Name:        entry.title,
+                        Description: strings.Join(entry.description, "\n"),
+                })
+        }
 }
 
 // extractCertifications extracts certifications
 func (p *Parser) extractCertifications(resume *models.Resume, text string) {
+        // Text is already the certifications section
+        if isSegmented(resume) {
+                for _, entry := range groupEntries(text) {
+                        resume.Certifications = append(resume.Certifications, entry.title)
+                }
+                return
+        }
+
         certRegex := regexp.MustCompile(`(?i)(certification|certified|certificate)`)
         lines := strings.Split(text, "\n")
         
@@ -314,29 +456,103 @@ func (p *Parser) extractCertifications(resume *models.Resume, text string) {
         }
 }
 
-// analyzeFormat analyzes resume formatting for ATS compatibility
-func (p *Parser) analyzeFormat(resume *models.Resume, text string) {
-        var issues []string
-        
-        // Check for tables (simple heuristic)
-        if strings.Contains(text, "\t") || regexp.MustCompile(`\s{5,}`).MatchString(text) {
-                issues = append(issues, "Document may contain tables or complex formatting")
+// sectionEntry is a titled entry of a projects or certifications section
+type sectionEntry struct {
+        title       string
+        description []string
+        bulleted    bool
+        depth       int
+}
+
+var (
+        entryBulletRegex = regexp.MustCompile(`^(\s*)(?:[•·▪◦●○■□►▸‣⁃*+\-–—]|\d{1,2}[.)])\s+`)
+        entryDateRegex   = regexp.MustCompile(`(?i)[(\[]?(?:\b(?:issued|obtained|earned|completed|awarded|expires?|expiry|expiration|valid until|valid through)\b\s*:?\s*)?\b` +
+                dateTokenPattern + `(?:` + rangeSeparator + `(?:` + dateTokenPattern + `|` + presentPattern + `))?\b[)\]]?`)
+        entryLabelRegex = regexp.MustCompile(`^[A-Za-z][A-Za-z ]{1,20}:\s`)
+)
+
+// groupEntries splits a projects or certifications section into entries.
+// An entry starts at a non-bullet line, or at a title-like bullet in a list
+// of bullets; the bullets and sentences that follow are its description.
+// Lines holding only dates are metadata and never start an entry.
+func groupEntries(text string) []sectionEntry {
+        var entries []sectionEntry
+
+        for _, line := range strings.Split(text, "\n") {
+                if strings.TrimSpace(line) == "" {
+                        continue
+                }
+
+                content := strings.TrimSpace(line)
+                bulleted := false
+                depth := 0
+                if m := entryBulletRegex.FindStringSubmatch(line); m != nil {
+                        content = strings.TrimSpace(line[len(m[0]):])
+                        bulleted = true
+                        depth = len(m[1])
+                }
+
+                title := entryDateRegex.ReplaceAllString(content, "")
+                title = strings.TrimSpace(strings.Trim(title, " \t|,;:-–—"))
+                if title == "" {
+                        continue
+                }
+
+                var current *sectionEntry
+                if len(entries) > 0 {
+                        current = &entries[len(entries)-1]
+                }
+
+                var starts bool
+                switch {
+                case current == nil:
+                        starts = true
+                case !bulleted:
+                        starts = isEntryTitle(title)
+                case !current.bulleted || depth > current.depth:
+                        // Bullets under a title line or a nested bullet
+                        starts = false
+                default:
+                        starts = isEntryTitle(title)
+                }
+
+                if starts {
+                        entries = append(entries, sectionEntry{title: title, bulleted: bulleted, depth: depth})
+                } else {
+                        current.description = append(current.description, content)
+                }
         }
-        
-        // Check for special characters that might indicate formatting
-        if regexp.MustCompile(`[│┌┐└┘├┤┬┴┼]`).MatchString(text) {
-                issues = append(issues, "Document contains table borders or special formatting")
+
+        return entries
+}
+
+// isEntryTitle reports whether a line reads as the name of a project or
+// certification rather than a sentence or a labelled detail
+// ("Tech stack: Go")
+func isEntryTitle(line string) bool {
+        if len(line) < 3 || len(strings.Fields(line)) > 10 {
+                return false
         }
-        
-        // Check for multiple columns (heuristic)
-        lines := strings.Split(text, "\n")
-        for _, line := range lines {
-                if len(strings.Fields(line)) > 10 {
-                        issues = append(issues, "Possible multi-column layout detected")
-                        break
+        if strings.HasSuffix(line, ".") || strings.HasSuffix(line, "!") {
+                return false
+        }
+        return !entryLabelRegex.MatchString(line)
+}
+
+// analyzeFormat analyzes resume formatting for ATS compatibility from the
+// document's structure: the issues its extractor found in the file, then
+// tables, columns and headings among the extracted blocks
+func (p *Parser) analyzeFormat(resume *models.Resume, blocks []models.TextBlock, inspected []models.FormatIssue) {
+        issues := inspected
+
+        // The file's own structure is more precise than the blocks
+        for _, issue := range blockFormatIssues(blocks) {
+                if !hasFormatIssue(inspected, issue.Code) {
+                        issues = append(issues, issue)
                 }
         }
-        
+        issues = append(issues, headingFormatIssues(resume, blocks)...)
+
         resume.FormatIssues = issues
 }
 
@@ -366,38 +582,159 @@ func (p *Parser) extractJDCompany(jd *models.JobDescription, text string) {
 }
 
 func (p *Parser) extractJDSkills(jd *models.JobDescription, text string) {
-        // Extract skills using similar logic as resume
-        skillKeywords := []string{
-                "python", "java", "javascript", "typescript", "go", "golang", "rust", "c++", "c#",
-                "react", "angular", "vue", "nodejs", "express", "django", "flask", "spring",
-                "sql", "mysql", "postgresql", "mongodb", "redis", "elasticsearch",
-                "aws", "azure", "gcp", "docker", "kubernetes", "terraform", "ansible",
-                "git", "github", "gitlab", "jenkins", "ci/cd", "devops",
-                "machine learning", "deep learning", "tensorflow", "pytorch", "scikit-learn",
-                "html", "css", "bootstrap", "tailwind", "sass", "less",
+        general, required, preferred := p.splitJDRequirements(text)
+
+        // Skills listed under an explicit requirements block are required,
+        // skills only listed as nice-to-have are preferred, and anything else
+        // mentioned in the posting is treated as required
+        explicitRequired := p.skills.FindSkills(required)
+        preferredSkills := p.skills.FindSkills(preferred)
+        generalSkills := p.skills.FindSkills(general)
+
+        isPreferred := make(map[string]bool)
+        for _, skill := range preferredSkills {
+                isPreferred[skill] = true
         }
-        
-        textLower := strings.ToLower(text)
-        var requiredSkills []string
-        
-        for _, skill := range skillKeywords {
-                if strings.Contains(textLower, strings.ToLower(skill)) {
+
+        requiredSkills := explicitRequired
+        for _, skill := range generalSkills {
+                if !isPreferred[skill] {
                         requiredSkills = append(requiredSkills, skill)
                 }
         }
-        
         jd.RequiredSkills = utils.RemoveDuplicates(requiredSkills)
+
+        isRequired := make(map[string]bool)
+        for _, skill := range jd.RequiredSkills {
+                isRequired[skill] = true
+        }
+        for _, skill := range preferredSkills {
+                if !isRequired[skill] {
+                        jd.PreferredSkills = append(jd.PreferredSkills, skill)
+                }
+        }
 }
 
 func (p *Parser) extractJDExperience(jd *models.JobDescription, text string) {
-        expRegex := regexp.MustCompile(`(\d+)\s*\+?\s*year`)
-        if match := expRegex.FindStringSubmatch(strings.ToLower(text)); len(match) > 1 {
-                if years, err := strconv.Atoi(match[1]); err == nil {
-                        jd.MinExperience = years
+        general, required, preferred := p.splitJDRequirements(text)
+
+        jd.ExperienceRequirements = append(append(
+                p.findExperienceRequirements(general, true, false),
+                p.findExperienceRequirements(required, true, true)...),
+                p.findExperienceRequirements(preferred, false, true)...)
+
+        // Minimum overall experience is the required general requirement
+        // when stated, otherwise the strictest required per-skill requirement
+        for _, req := range jd.ExperienceRequirements {
+                if req.Required && req.Skill == "" && req.Domain == "" && req.MinYears > jd.MinExperience {
+                        jd.MinExperience = req.MinYears
+                }
+        }
+        if jd.MinExperience == 0 {
+                for _, req := range jd.ExperienceRequirements {
+                        if req.Required && req.MinYears > jd.MinExperience {
+                                jd.MinExperience = req.MinYears
+                        }
                 }
         }
 }
 
+// findExperienceRequirements extracts "N+ years of X" phrases. The phrase
+// after each number runs up to the next number of years or the end of the
+// clause, so "3+ years of Python and 5+ years in cloud" yields two entries.
+// Only phrases about experience count: the clause says "experience", or,
+// inside a requirements block, the phrase names a skill or field of work.
+// Company age, tenure and benefits ("in business for 25 years", "10 years
+// of tenure") are skipped.
+func (p *Parser) findExperienceRequirements(text string, required, requirementsBlock bool) []models.ExperienceRequirement {
+        var requirements []models.ExperienceRequirement
+        matches := jdYearsRegex.FindAllStringSubmatchIndex(text, -1)
+
+        for i, match := range matches {
+                years, err := strconv.Atoi(text[match[2]:match[3]])
+                if err != nil || years == 0 || years > 40 {
+                        continue
+                }
+
+                phraseEnd := len(text)
+                if i+1 < len(matches) {
+                        phraseEnd = matches[i+1][0]
+                }
+                phrase := text[match[1]:phraseEnd]
+                if idx := strings.IndexAny(phrase, ".;\n()"); idx >= 0 {
+                        phrase = phrase[:idx]
+                }
+
+                // The company's own years ("we have been in business for 25
+                // years") are stated before the number, benefits after it
+                clauseStart := strings.LastIndexAny(text[:match[0]], ".;\n") + 1
+                clause := text[clauseStart:match[0]] + phrase
+                if jdCompanyContextRegex.MatchString(text[clauseStart:match[0]]) || jdNotExperienceRegex.MatchString(clause) {
+                        continue
+                }
+                skills := p.skills.FindSkills(phrase)
+                domain := experienceDomain(phrase)
+                if !jdExperienceWordRegex.MatchString(clause) &&
+                        !(requirementsBlock && (len(skills) > 0 || isExperienceDomain(domain))) {
+                        continue
+                }
+
+                base := models.ExperienceRequirement{
+                        MinYears: years,
+                        Required: required,
+                        Text:     strings.TrimSpace(jdTrailingConjunctionRegex.ReplaceAllString(text[match[0]:match[1]]+phrase, "")),
+                }
+
+                if len(skills) > 0 {
+                        for _, skill := range skills {
+                                req := base
+                                req.Skill = skill
+                                requirements = append(requirements, req)
+                        }
+                        continue
+                }
+
+                base.Domain = domain
+                requirements = append(requirements, base)
+        }
+
+        return requirements
+}
+
+// experienceDomain reduces the phrase after "N years" to a short domain such
+// as "cloud" or "software development", or "" for general experience
+func experienceDomain(phrase string) string {
+        phrase = strings.ToLower(phrase)
+        phrase = jdDomainNoiseRegex.ReplaceAllString(phrase, " ")
+
+        var words []string
+        for _, word := range strings.Fields(phrase) {
+                word = strings.Trim(word, ",:-")
+                if word == "and" || word == "or" {
+                        break
+                }
+                if word != "" {
+                        words = append(words, word)
+                }
+                if len(words) == 3 {
+                        break
+                }
+        }
+
+        return strings.Join(words, " ")
+}
+
+// isExperienceDomain reports whether a domain from experienceDomain is a
+// field of work
+func isExperienceDomain(domain string) bool {
+        for _, word := range strings.Fields(domain) {
+                if experienceDomainWords[word] {
+                        return true
+                }
+        }
+        return false
+}
+
 func (p *Parser) extractJDEducation(jd *models.JobDescription, text string) {
         degreeRegex := regexp.MustCompile(`(?i)(bachelor|master|phd|b\.?s\.?|m\.?s\.?|b\.?a\.?|m\.?a\.?|b\.?tech|m\.?tech|mba|diploma)`)
         matches := degreeRegex.FindAllString(text, -1)
@@ -412,24 +749,12 @@ func (p *Parser) extractJDLocation(jd *models.JobDescription, text string) {
 }
 
 func (p *Parser) extractJDKeywords(jd *models.JobDescription, text string) {
-        // Extract important keywords using TF-IDF
-        jd.Keywords = p.nlp.ExtractKeywords(text, 20)
-}
-
-// parseDate parses date string to time.Time
-func (p *Parser) parseDate(dateStr string) (time.Time, error) {
-        formats := []string{
-                "Jan 2006", "January 2006", "2006",
-                "Jan 02, 2006", "January 02, 2006",
-        }
-        
-        for _, format := range formats {
-                if t, err := time.Parse(format, dateStr); err == nil {
-                        return t, nil
-                }
+        // Rank keywords by BM25 against the keyword corpus
+        jd.KeywordWeights = p.nlp.RankKeywords(text, 20)
+        jd.Keywords = make([]string, len(jd.KeywordWeights))
+        for i, keyword := range jd.KeywordWeights {
+                jd.Keywords[i] = keyword.Term
         }
-        
-        return time.Time{}, fmt.Errorf("unable to parse date: %s", dateStr)
 }
 
 // Helper functions
@@ -0,0 +1,226 @@
+package services
+
+import (
+	"ats-analyzer/models"
+	"strings"
+	"testing"
+)
+
+func TestExtractJDExperience(t *testing.T) {
+	tests := []struct {
+		name          string
+		text          string
+		minExperience int
+		requirements  []models.ExperienceRequirement
+	}{
+		{
+			name:          "general requirement",
+			text:          "Requirements:\n- 5+ years of professional experience",
+			minExperience: 5,
+			requirements:  []models.ExperienceRequirement{{MinYears: 5, Required: true}},
+		},
+		{
+			name:          "per-skill and domain requirements",
+			text:          "Requirements:\n- 3+ years of Python and 5+ years in cloud",
+			minExperience: 5,
+			requirements: []models.ExperienceRequirement{
+				{Skill: "python", MinYears: 3, Required: true},
+				{Domain: "cloud", MinYears: 5, Required: true},
+			},
+		},
+		{
+			name:          "experience outside a requirements block",
+			text:          "You will bring 4 years of experience building APIs.",
+			minExperience: 4,
+			requirements:  []models.ExperienceRequirement{{Domain: "building apis", MinYears: 4, Required: true}},
+		},
+		{
+			name:          "preferred experience does not set the minimum",
+			text:          "Requirements:\n- 2 years of experience\nNice to have:\n- 8 years of experience",
+			minExperience: 2,
+			requirements: []models.ExperienceRequirement{
+				{MinYears: 2, Required: true},
+				{MinYears: 8},
+			},
+		},
+		{
+			name:          "company age",
+			text:          "About us:\nWe have been in business for 25 years.\nRequirements:\n- 3 years of experience",
+			minExperience: 3,
+			requirements:  []models.ExperienceRequirement{{MinYears: 3, Required: true}},
+		},
+		{
+			name: "company age with experience wording",
+			text: "Our company has 30 years of experience serving clients.",
+		},
+		{
+			name: "tenure",
+			text: "Requirements:\n- 10 years of tenure",
+		},
+		{
+			name: "benefits",
+			text: "Benefits:\n- Stock options vesting over 4 years\n- 25 days vacation after 2 years",
+		},
+		{
+			name: "unrelated number outside a requirements block",
+			text: "Join a team that has shipped software to millions for over 12 years.",
+		},
+	}
+
+	parser := NewParser()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jd := &models.JobDescription{}
+			parser.extractJDExperience(jd, tt.text)
+
+			if jd.MinExperience != tt.minExperience {
+				t.Errorf("MinExperience = %d, want %d", jd.MinExperience, tt.minExperience)
+			}
+			if len(jd.ExperienceRequirements) != len(tt.requirements) {
+				t.Fatalf("got %d requirements %+v, want %d", len(jd.ExperienceRequirements), jd.ExperienceRequirements, len(tt.requirements))
+			}
+			for i, want := range tt.requirements {
+				got := jd.ExperienceRequirements[i]
+				if got.Skill != want.Skill || got.Domain != want.Domain || got.MinYears != want.MinYears || got.Required != want.Required {
+					t.Errorf("requirement %d = %+v, want %+v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestGroupEntries(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		entries []sectionEntry
+	}{
+		{
+			name: "titles with bullets and dates",
+			text: "ATS Analyzer\nJan 2023 - Present\n• Built a resume scoring service in Go\n• Cut parsing time by 40%\nHome Lab | 2021\n- Runs Kubernetes on three Raspberry Pis",
+			entries: []sectionEntry{
+				{title: "ATS Analyzer", description: []string{"Built a resume scoring service in Go", "Cut parsing time by 40%"}},
+				{title: "Home Lab", description: []string{"Runs Kubernetes on three Raspberry Pis"}},
+			},
+		},
+		{
+			name: "list of bulleted certifications",
+			text: "• AWS Certified Solutions Architect (Issued Mar 2021)\n• Certified Kubernetes Administrator\n  ◦ Expires: June 2025\n• PMP, 2019",
+			entries: []sectionEntry{
+				{title: "AWS Certified Solutions Architect"},
+				{title: "Certified Kubernetes Administrator"},
+				{title: "PMP"},
+			},
+		},
+		{
+			name: "bulleted titles with nested details",
+			text: "• Budget Tracker\n  ◦ Personal finance app used by 2,000 people\n  ◦ React and Firebase",
+			entries: []sectionEntry{
+				{title: "Budget Tracker", description: []string{"Personal finance app used by 2,000 people", "React and Firebase"}},
+			},
+		},
+		{
+			name: "sentences and labelled details",
+			text: "Open Source Contributions\nFixed memory leaks in a popular HTTP router.\nTech stack: Go, Redis",
+			entries: []sectionEntry{
+				{title: "Open Source Contributions", description: []string{"Fixed memory leaks in a popular HTTP router.", "Tech stack: Go, Redis"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries := groupEntries(tt.text)
+			if len(entries) != len(tt.entries) {
+				t.Fatalf("got %d entries %+v, want %d", len(entries), entries, len(tt.entries))
+			}
+			for i, want := range tt.entries {
+				got := entries[i]
+				if got.title != want.title || strings.Join(got.description, "|") != strings.Join(want.description, "|") {
+					t.Errorf("entry %d = %q %q, want %q %q", i, got.title, got.description, want.title, want.description)
+				}
+			}
+		})
+	}
+}
+
+func TestExtractExperience(t *testing.T) {
+	type role struct {
+		position, company, description string
+	}
+	tests := []struct {
+		name  string
+		text  string
+		roles []role
+	}{
+		{
+			name: "consecutive roles with headers above the dates",
+			text: "Senior Software Engineer\nAcme Corp\nJan 2020 - Present\n- Led a team of 5\n- Built billing services in Go\n" +
+				"Software Engineer\nGlobex\nJun 2017 - Dec 2019\n- Maintained the public API",
+			roles: []role{
+				{"Senior Software Engineer", "Acme Corp", "- Led a team of 5\n- Built billing services in Go"},
+				{"Software Engineer", "Globex", "- Maintained the public API"},
+			},
+		},
+		{
+			name: "company first and sentences in the description",
+			text: "Globex Corporation\nMarketing Manager\n2018 - 2020\nRan campaigns across Europe.\nGrew the mailing list to 40,000.\n\n" +
+				"Initech\nMarketing Intern\nJun 2017 - Aug 2017\nWrote copy for product launches.",
+			roles: []role{
+				{"Marketing Manager", "Globex Corporation", "Ran campaigns across Europe.\nGrew the mailing list to 40,000."},
+				{"Marketing Intern", "Initech", "Wrote copy for product launches."},
+			},
+		},
+		{
+			name: "position beside the dates",
+			text: "Acme Corp\nData Analyst | Mar 2019 - Present\n• Built the sales dashboards",
+			roles: []role{
+				{"Data Analyst", "Acme Corp", "• Built the sales dashboards"},
+			},
+		},
+		{
+			name: "company beside the dates after an unbulleted description",
+			text: "Senior Backend Engineer\nStark Industries Jan 2018 - Present\nBuilt Go microservices\nLed migration of the billing platform to AWS\n" +
+				"Backend Engineer\nHydra Labs Mar 2014 - Dec 2017\nMaintained Python data pipelines",
+			roles: []role{
+				{"Senior Backend Engineer", "Stark Industries", "Built Go microservices\nLed migration of the billing platform to AWS"},
+				{"Backend Engineer", "Hydra Labs", "Maintained Python data pipelines"},
+			},
+		},
+		{
+			name: "position and company on one line",
+			text: "Senior Go Developer, Acme Corp\n2019 - 2022\n- Mentored engineers\nResearch Scientist at Institut Radium\n2015 - 2019\n- Led Python data pipelines",
+			roles: []role{
+				{"Senior Go Developer", "Acme Corp", "- Mentored engineers"},
+				{"Research Scientist", "Institut Radium", "- Led Python data pipelines"},
+			},
+		},
+		{
+			name: "dates opening each entry",
+			text: "2021 - Present\nProduct Designer\nUmbrella Health\n- Redesigned onboarding\n" +
+				"2019 - 2021\nUX Designer\nHooli\n- Ran usability tests",
+			roles: []role{
+				{"Product Designer", "Umbrella Health", "- Redesigned onboarding"},
+				{"UX Designer", "Hooli", "- Ran usability tests"},
+			},
+		},
+	}
+
+	parser := NewParser()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resume := &models.Resume{}
+			parser.extractExperience(resume, tt.text)
+
+			if len(resume.Experience) != len(tt.roles) {
+				t.Fatalf("got %d roles %+v, want %d", len(resume.Experience), resume.Experience, len(tt.roles))
+			}
+			for i, want := range tt.roles {
+				got := resume.Experience[i]
+				if got.Position != want.position || got.Company != want.company || got.Description != want.description {
+					t.Errorf("role %d = %q at %q: %q, want %q at %q: %q", i, got.Position, got.Company, got.Description, want.position, want.company, want.description)
+				}
+			}
+		})
+	}
+}
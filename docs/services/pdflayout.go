@@ -0,0 +1,471 @@
+package services
+
+import (
+	"ats-analyzer/models"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// Layout thresholds, as multiples of the font size
+const (
+	// pdfLineTolerance is how far apart baselines on one line may be
+	pdfLineTolerance = 0.4
+	// pdfWordGap is the smallest gap between glyphs that separates words
+	pdfWordGap = 0.2
+	// pdfSpanGap is the smallest gap that splits a line into spans, such as
+	// text either side of a tab stop or a column gutter
+	pdfSpanGap = 1.0
+	// pdfMinGutter is the narrowest gap between two columns
+	pdfMinGutter = 1.2
+	// pdfHeadingRatio is how much larger than body text a heading is set
+	pdfHeadingRatio = 1.15
+)
+
+// pdfMinColumnShare is the smallest share of a page's glyphs each side of a
+// gutter must hold, so right-aligned dates do not count as a column
+const pdfMinColumnShare = 0.1
+
+// pdfMaxHeadingWords is the longest line treated as a heading
+const pdfMaxHeadingWords = 8
+
+// pdfGutterBins bounds the histogram findPDFGutters builds across the text,
+// whose width comes from coordinates in the file. Bins are one point wide
+// on any real page and only widen for absurd coordinates.
+const pdfGutterBins = 4096
+
+// pdfSpan is a run of glyphs on one line without a wide gap
+type pdfSpan struct {
+	glyphs []pdf.Text
+	x0, x1 float64
+	// column is the span's column, or -1 when it crosses a gutter
+	column int
+}
+
+// pdfLine is the spans sharing a baseline, left to right
+type pdfLine struct {
+	y     float64
+	spans []*pdfSpan
+}
+
+// pdfGutter is an empty vertical strip separating two columns
+type pdfGutter struct {
+	start, end float64
+}
+
+// pdfLayoutBlocks rebuilds the lines of every page from glyph positions and
+// returns them in reading order: text spanning the page first, then each
+// column top to bottom. Lines set larger than the body text become headings.
+func pdfLayoutBlocks(reader *pdf.Reader) ([]models.TextBlock, error) {
+	var blocks []models.TextBlock
+	for i := 1; i <= reader.NumPage(); i++ {
+		page := reader.Page(i)
+		if page.V.IsNull() {
+			continue
+		}
+
+		glyphs, err := pdfPageGlyphs(page)
+		if err != nil {
+			return nil, fmt.Errorf("page %d: %v", i, err)
+		}
+		blocks = append(blocks, pdfPageBlocks(i, glyphs)...)
+	}
+
+	markPDFHeadings(blocks)
+	return blocks, nil
+}
+
+// pdfPageGlyphs returns the positioned glyphs on a page. The PDF reader
+// panics on content it cannot interpret, which is returned as an error.
+func pdfPageGlyphs(page pdf.Page) (glyphs []pdf.Text, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("failed to read page content: %v", recovered)
+		}
+	}()
+
+	for _, glyph := range page.Content().Text {
+		// The reader adds a newline after each TJ array
+		if glyph.S == "" || glyph.S == "\n" || glyph.S == "\r" {
+			continue
+		}
+		glyphs = append(glyphs, glyph)
+	}
+	return glyphs, nil
+}
+
+// pdfPageBlocks lays out the glyphs of one page as blocks in reading order
+func pdfPageBlocks(pageNumber int, glyphs []pdf.Text) []models.TextBlock {
+	lines := groupPDFLines(glyphs)
+	if len(lines) == 0 {
+		return nil
+	}
+
+	var gutters []pdfGutter
+	if pdfWidthsKnown(glyphs) {
+		gutters = findPDFGutters(lines, pdfModeSize(glyphs))
+	}
+	assignPDFColumns(lines, gutters)
+
+	var blocks []models.TextBlock
+	// Lines between two spanning lines are read column by column
+	band := make([][]models.TextBlock, len(gutters)+1)
+	flush := func() {
+		for column := range band {
+			blocks = append(blocks, band[column]...)
+			band[column] = nil
+		}
+	}
+
+	// Columns start at the first line with text in two of them, so a
+	// header above the columns is read first even when it sits over one
+	first, last := len(lines), -1
+	for i, line := range lines {
+		if pdfLineColumns(line) > 1 {
+			if i < first {
+				first = i
+			}
+			last = i
+		}
+	}
+
+	for i, line := range lines {
+		if i < first || i > last || pdfLineColumns(line) < 0 {
+			flush()
+			blocks = append(blocks, pdfLineBlock(pageNumber, 0, line.y, line.spans))
+			continue
+		}
+
+		for column := range band {
+			var spans []*pdfSpan
+			for _, span := range line.spans {
+				if span.column == column {
+					spans = append(spans, span)
+				}
+			}
+			if len(spans) > 0 {
+				band[column] = append(band[column], pdfLineBlock(pageNumber, column+1, line.y, spans))
+			}
+		}
+	}
+	flush()
+
+	return blocks
+}
+
+// pdfLineColumns returns how many columns a line has text in, or -1 when
+// it crosses a gutter
+func pdfLineColumns(line pdfLine) int {
+	columns := make(map[int]bool)
+	for _, span := range line.spans {
+		if span.column < 0 {
+			return -1
+		}
+		columns[span.column] = true
+	}
+	return len(columns)
+}
+
+// groupPDFLines sorts glyphs top to bottom into lines, each split into
+// spans at wide gaps
+func groupPDFLines(glyphs []pdf.Text) []pdfLine {
+	sorted := append([]pdf.Text(nil), glyphs...)
+	// Stable sorts keep the content stream's order for glyphs without
+	// widths, which all share a position
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Y > sorted[j].Y
+	})
+
+	var rows [][]pdf.Text
+	var rowY, rowSize float64
+	for _, glyph := range sorted {
+		size := math.Max(glyph.FontSize, rowSize)
+		if len(rows) > 0 && rowY-glyph.Y <= pdfLineTolerance*size {
+			rows[len(rows)-1] = append(rows[len(rows)-1], glyph)
+			rowSize = size
+			continue
+		}
+		rows = append(rows, []pdf.Text{glyph})
+		rowY, rowSize = glyph.Y, glyph.FontSize
+	}
+
+	lines := make([]pdfLine, 0, len(rows))
+	for _, row := range rows {
+		sort.SliceStable(row, func(i, j int) bool {
+			return row[i].X < row[j].X
+		})
+		spans := splitPDFSpans(dedupePDFGlyphs(row))
+		if len(spans) > 0 {
+			lines = append(lines, pdfLine{y: row[0].Y, spans: spans})
+		}
+	}
+	return lines
+}
+
+// dedupePDFGlyphs drops glyphs drawn twice at almost the same position,
+// which some generators do to fake bold text
+func dedupePDFGlyphs(row []pdf.Text) []pdf.Text {
+	result := row[:0:0]
+	for _, glyph := range row {
+		if n := len(result); n > 0 {
+			prev := result[n-1]
+			if prev.W > 0 && prev.S == glyph.S && math.Abs(prev.X-glyph.X) < 0.5 && math.Abs(prev.Y-glyph.Y) < 0.5 {
+				continue
+			}
+		}
+		result = append(result, glyph)
+	}
+	return result
+}
+
+// splitPDFSpans splits a line's glyphs at gaps wider than pdfSpanGap
+func splitPDFSpans(row []pdf.Text) []*pdfSpan {
+	var spans []*pdfSpan
+	var current *pdfSpan
+	for _, glyph := range row {
+		if strings.TrimSpace(glyph.S) == "" {
+			if current != nil {
+				current.glyphs = append(current.glyphs, glyph)
+			}
+			continue
+		}
+
+		// Without glyph widths the gap cannot be measured
+		if current != nil && current.x1 > current.x0 && glyph.X-current.x1 > pdfSpanGap*glyph.FontSize {
+			current = nil
+		}
+		if current == nil {
+			current = &pdfSpan{x0: glyph.X, x1: glyph.X}
+			spans = append(spans, current)
+		}
+		current.glyphs = append(current.glyphs, glyph)
+		current.x1 = math.Max(current.x1, glyph.X+glyph.W)
+	}
+	return spans
+}
+
+// pdfWidthsKnown reports whether the fonts carry glyph widths. Without
+// them every glyph of a string shares one position and columns cannot be
+// told apart.
+func pdfWidthsKnown(glyphs []pdf.Text) bool {
+	var total, measured int
+	for _, glyph := range glyphs {
+		if strings.TrimSpace(glyph.S) == "" {
+			continue
+		}
+		total++
+		if glyph.W > 0 {
+			measured++
+		}
+	}
+	return total > 0 && measured*10 >= total*9
+}
+
+// findPDFGutters finds vertical strips that almost no line crosses and
+// that have a fair share of the page's text on either side. A few lines,
+// such as a name centred above two columns, may cross a gutter.
+func findPDFGutters(lines []pdfLine, bodySize float64) []pdfGutter {
+	minX, maxX := math.Inf(1), math.Inf(-1)
+	total := 0
+	for _, line := range lines {
+		for _, span := range line.spans {
+			minX = math.Min(minX, span.x0)
+			maxX = math.Max(maxX, span.x1)
+			total += len(span.glyphs)
+		}
+	}
+	extent := maxX - minX
+	if extent <= 0 || math.IsInf(extent, 0) || math.IsNaN(extent) || bodySize <= 0 {
+		return nil
+	}
+	step := math.Max(1, extent/pdfGutterBins)
+	width := int(math.Ceil(extent / step))
+
+	// How many lines have text in each bin across the page
+	coverage := make([]int, width+1)
+	for _, line := range lines {
+		for _, span := range line.spans {
+			for x := int((span.x0 - minX) / step); x < int(math.Ceil((span.x1-minX)/step)) && x <= width; x++ {
+				coverage[x]++
+			}
+		}
+	}
+	tolerance := len(lines) / 10
+
+	var gutters []pdfGutter
+	for x := 0; x <= width; {
+		if coverage[x] > tolerance {
+			x++
+			continue
+		}
+		start := x
+		for x <= width && coverage[x] <= tolerance {
+			x++
+		}
+
+		gutter := pdfGutter{start: minX + float64(start)*step, end: minX + float64(x)*step}
+		if start == 0 || x > width || gutter.end-gutter.start < pdfMinGutter*bodySize {
+			continue
+		}
+
+		left, right := 0, 0
+		for _, line := range lines {
+			for _, span := range line.spans {
+				switch {
+				case span.x1 <= gutter.start:
+					left += len(span.glyphs)
+				case span.x0 >= gutter.end:
+					right += len(span.glyphs)
+				}
+			}
+		}
+		share := pdfMinColumnShare * float64(total)
+		if float64(left) >= share && float64(right) >= share {
+			gutters = append(gutters, gutter)
+		}
+	}
+	return gutters
+}
+
+// assignPDFColumns numbers each span's column from zero by where it
+// starts, marking spans that run across a gutter with -1. A span may reach
+// into a gutter, as the few lines tolerated there do.
+func assignPDFColumns(lines []pdfLine, gutters []pdfGutter) {
+	for _, line := range lines {
+		for _, span := range line.spans {
+			span.column = 0
+			for _, gutter := range gutters {
+				switch {
+				case span.x0 < gutter.start && span.x1 > gutter.end:
+					span.column = -1
+				case span.x0 >= (gutter.start+gutter.end)/2 && span.column >= 0:
+					span.column++
+				}
+			}
+		}
+	}
+}
+
+// pdfLineBlock joins spans of one line into a block. Spans are separated by
+// two spaces, as table cells are.
+func pdfLineBlock(pageNumber, column int, y float64, spans []*pdfSpan) models.TextBlock {
+	texts := make([]string, 0, len(spans))
+	var glyphs []pdf.Text
+	for _, span := range spans {
+		texts = append(texts, pdfSpanText(span))
+		glyphs = append(glyphs, span.glyphs...)
+	}
+
+	first, last := spans[0], spans[len(spans)-1]
+	return models.TextBlock{
+		Kind: models.BlockParagraph,
+		Text: strings.Join(texts, "  "),
+		Layout: &models.BlockLayout{
+			Page:     pageNumber,
+			Column:   column,
+			X:        first.x0,
+			Y:        y,
+			Width:    last.x1 - first.x0,
+			FontSize: pdfModeSize(glyphs),
+		},
+	}
+}
+
+// pdfSpanText joins a span's glyphs, adding spaces at gaps between words
+// where the PDF positions words rather than drawing a space
+func pdfSpanText(span *pdfSpan) string {
+	var text strings.Builder
+	var prev *pdf.Text
+	space := false
+	for i := range span.glyphs {
+		glyph := &span.glyphs[i]
+		if strings.TrimSpace(glyph.S) == "" {
+			space = true
+			continue
+		}
+		if prev != nil && prev.W > 0 && glyph.X-(prev.X+prev.W) > pdfWordGap*glyph.FontSize {
+			space = true
+		}
+		if space && text.Len() > 0 {
+			text.WriteString(" ")
+		}
+		space = false
+		text.WriteString(glyph.S)
+		prev = glyph
+	}
+	return text.String()
+}
+
+// pdfModeSize returns the font size most glyphs use, to half a point
+func pdfModeSize(glyphs []pdf.Text) float64 {
+	counts := make(map[float64]int)
+	best, bestCount := 0.0, 0
+	for _, glyph := range glyphs {
+		if strings.TrimSpace(glyph.S) == "" {
+			continue
+		}
+		size := math.Round(glyph.FontSize*2) / 2
+		counts[size]++
+		if counts[size] > bestCount || counts[size] == bestCount && size < best {
+			best, bestCount = size, counts[size]
+		}
+	}
+	return best
+}
+
+// markPDFHeadings turns short lines set noticeably larger than the body
+// text into headings, the largest size being level 1
+func markPDFHeadings(blocks []models.TextBlock) {
+	counts := make(map[float64]int)
+	for _, block := range blocks {
+		counts[block.Layout.FontSize] += len(block.Text)
+	}
+	body, bodyCount := 0.0, 0
+	for size, count := range counts {
+		if count > bodyCount || count == bodyCount && size < body {
+			body, bodyCount = size, count
+		}
+	}
+	if body == 0 {
+		return
+	}
+
+	var sizes []float64
+	for size := range counts {
+		if size >= body*pdfHeadingRatio {
+			sizes = append(sizes, size)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.Float64Slice(sizes)))
+
+	for i := range blocks {
+		block := &blocks[i]
+		if block.Layout.FontSize < body*pdfHeadingRatio || !isPDFHeadingText(block.Text) {
+			continue
+		}
+		block.Kind = models.BlockHeading
+		for level, size := range sizes {
+			if size == block.Layout.FontSize {
+				block.Level = level + 1
+			}
+		}
+		if block.Level > 6 {
+			block.Level = 6
+		}
+	}
+}
+
+// isPDFHeadingText reports whether a line is short enough for a heading and
+// does not read like a sentence
+func isPDFHeadingText(text string) bool {
+	words := strings.Fields(text)
+	if len(words) == 0 || len(words) > pdfMaxHeadingWords {
+		return false
+	}
+	last := []rune(text)[len([]rune(text))-1]
+	return last != '.' && last != ',' && (unicode.IsLetter(last) || unicode.IsDigit(last) || last == ':' || last == ')')
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// twoColumnLines lays out rows of two spans, the right one starting at
+// rightX, plus one span far out at farX
+func twoColumnLines(rightX, farX float64) []pdfLine {
+	glyphs := make([]pdf.Text, 20)
+	var lines []pdfLine
+	for i := 0; i < 20; i++ {
+		lines = append(lines, pdfLine{y: float64(i) * 12, spans: []*pdfSpan{
+			{glyphs: glyphs, x0: 50, x1: 250},
+			{glyphs: glyphs, x0: rightX, x1: rightX + 200},
+		}})
+	}
+	lines = append(lines, pdfLine{y: 300, spans: []*pdfSpan{{glyphs: glyphs[:1], x0: farX, x1: farX + 5}}})
+	return lines
+}
+
+func TestFindPDFGutters(t *testing.T) {
+	gutters := findPDFGutters(twoColumnLines(320, 500), 10)
+	if len(gutters) != 1 || gutters[0].start != 250 || gutters[0].end != 320 {
+		t.Errorf("gutters = %+v, want one from 250 to 320", gutters)
+	}
+}
+
+func TestFindPDFGuttersUntrustedCoordinates(t *testing.T) {
+	for _, farX := range []float64{1e15, math.MaxFloat64 / 4, math.Inf(1), math.NaN()} {
+		gutters := findPDFGutters(twoColumnLines(320, farX), 10)
+		if len(gutters) > 1 {
+			t.Errorf("x = %g: gutters = %+v, want at most one", farX, gutters)
+		}
+	}
+}
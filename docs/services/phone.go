@@ -0,0 +1,281 @@
+package services
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	phoneCandidateRegex = regexp.MustCompile(`(?:\+\s?|\(\+?)?\d(?:[\d \t().\-/]*\d)?`)
+	phoneGroupRegex     = regexp.MustCompile(`\d+`)
+	phoneLabelRegex     = regexp.MustCompile(`(?i)\b(?:phone|tel|telephone|mobile|mob|cell|ph|whatsapp|contact)\.?\s*(?:no\.?|number|#)?\s*[:.]?\s*$`)
+	idLabelRegex        = regexp.MustCompile(`(?i)(?:\bid|\bno\.?|#|number|licen[cs]e|passport|ssn|gpa|zip|post\s?code)\s*[:.]?\s*$`)
+	yearRangeRegex      = regexp.MustCompile(`^(?:\d{1,2}[/.])?(?:19|20)\d{2}\s*[-/]\s*(?:\d{1,2}[/.])?(?:19|20)\d{2}$`)
+	dateRegex           = regexp.MustCompile(`^\d{1,4}[/.-]\d{1,2}[/.-]\d{1,4}$`)
+)
+
+// phoneCountry is a country's calling code and how many digits its
+// national numbers have once the trunk prefix dialled before them at home
+// is dropped
+type phoneCountry struct {
+	country   string
+	region    string
+	code      string
+	minDigits int
+	maxDigits int
+	trunk     string
+}
+
+// phoneCountries are the countries numbers are recognised for. Countries
+// sharing a code are listed most common first.
+var phoneCountries = []phoneCountry{
+	{country: "United States", region: "US", code: "1", minDigits: 10, maxDigits: 10, trunk: "1"},
+	{country: "Canada", region: "CA", code: "1", minDigits: 10, maxDigits: 10, trunk: "1"},
+	{country: "United Kingdom", region: "GB", code: "44", minDigits: 9, maxDigits: 10, trunk: "0"},
+	{country: "India", region: "IN", code: "91", minDigits: 10, maxDigits: 10, trunk: "0"},
+	{country: "Germany", region: "DE", code: "49", minDigits: 6, maxDigits: 11, trunk: "0"},
+	{country: "France", region: "FR", code: "33", minDigits: 9, maxDigits: 9, trunk: "0"},
+	{country: "Netherlands", region: "NL", code: "31", minDigits: 9, maxDigits: 9, trunk: "0"},
+	{country: "Belgium", region: "BE", code: "32", minDigits: 8, maxDigits: 9, trunk: "0"},
+	{country: "Luxembourg", region: "LU", code: "352", minDigits: 6, maxDigits: 9},
+	{country: "Spain", region: "ES", code: "34", minDigits: 9, maxDigits: 9},
+	{country: "Italy", region: "IT", code: "39", minDigits: 6, maxDigits: 11},
+	{country: "Portugal", region: "PT", code: "351", minDigits: 9, maxDigits: 9},
+	{country: "Ireland", region: "IE", code: "353", minDigits: 7, maxDigits: 9, trunk: "0"},
+	{country: "Switzerland", region: "CH", code: "41", minDigits: 9, maxDigits: 9, trunk: "0"},
+	{country: "Austria", region: "AT", code: "43", minDigits: 4, maxDigits: 13, trunk: "0"},
+	{country: "Sweden", region: "SE", code: "46", minDigits: 7, maxDigits: 9, trunk: "0"},
+	{country: "Norway", region: "NO", code: "47", minDigits: 8, maxDigits: 8},
+	{country: "Denmark", region: "DK", code: "45", minDigits: 8, maxDigits: 8},
+	{country: "Finland", region: "FI", code: "358", minDigits: 5, maxDigits: 10, trunk: "0"},
+	{country: "Poland", region: "PL", code: "48", minDigits: 9, maxDigits: 9},
+	{country: "Czech Republic", region: "CZ", code: "420", minDigits: 9, maxDigits: 9},
+	{country: "Hungary", region: "HU", code: "36", minDigits: 8, maxDigits: 9, trunk: "06"},
+	{country: "Romania", region: "RO", code: "40", minDigits: 9, maxDigits: 9, trunk: "0"},
+	{country: "Greece", region: "GR", code: "30", minDigits: 10, maxDigits: 10},
+	{country: "Turkey", region: "TR", code: "90", minDigits: 10, maxDigits: 10, trunk: "0"},
+	{country: "Russia", region: "RU", code: "7", minDigits: 10, maxDigits: 10, trunk: "8"},
+	{country: "Ukraine", region: "UA", code: "380", minDigits: 9, maxDigits: 9, trunk: "0"},
+	{country: "Israel", region: "IL", code: "972", minDigits: 8, maxDigits: 9, trunk: "0"},
+	{country: "United Arab Emirates", region: "AE", code: "971", minDigits: 8, maxDigits: 9, trunk: "0"},
+	{country: "Saudi Arabia", region: "SA", code: "966", minDigits: 8, maxDigits: 9, trunk: "0"},
+	{country: "Egypt", region: "EG", code: "20", minDigits: 9, maxDigits: 10, trunk: "0"},
+	{country: "Morocco", region: "MA", code: "212", minDigits: 9, maxDigits: 9, trunk: "0"},
+	{country: "South Africa", region: "ZA", code: "27", minDigits: 9, maxDigits: 9, trunk: "0"},
+	{country: "Nigeria", region: "NG", code: "234", minDigits: 8, maxDigits: 10, trunk: "0"},
+	{country: "Kenya", region: "KE", code: "254", minDigits: 9, maxDigits: 9, trunk: "0"},
+	{country: "Ghana", region: "GH", code: "233", minDigits: 9, maxDigits: 9, trunk: "0"},
+	{country: "Pakistan", region: "PK", code: "92", minDigits: 9, maxDigits: 10, trunk: "0"},
+	{country: "Bangladesh", region: "BD", code: "880", minDigits: 10, maxDigits: 10, trunk: "0"},
+	{country: "Sri Lanka", region: "LK", code: "94", minDigits: 9, maxDigits: 9, trunk: "0"},
+	{country: "Singapore", region: "SG", code: "65", minDigits: 8, maxDigits: 8},
+	{country: "Malaysia", region: "MY", code: "60", minDigits: 9, maxDigits: 10, trunk: "0"},
+	{country: "Indonesia", region: "ID", code: "62", minDigits: 9, maxDigits: 12, trunk: "0"},
+	{country: "Philippines", region: "PH", code: "63", minDigits: 10, maxDigits: 10, trunk: "0"},
+	{country: "Thailand", region: "TH", code: "66", minDigits: 8, maxDigits: 9, trunk: "0"},
+	{country: "Vietnam", region: "VN", code: "84", minDigits: 9, maxDigits: 10, trunk: "0"},
+	{country: "China", region: "CN", code: "86", minDigits: 10, maxDigits: 11, trunk: "0"},
+	{country: "Hong Kong", region: "HK", code: "852", minDigits: 8, maxDigits: 8},
+	{country: "Taiwan", region: "TW", code: "886", minDigits: 8, maxDigits: 9, trunk: "0"},
+	{country: "Japan", region: "JP", code: "81", minDigits: 9, maxDigits: 10, trunk: "0"},
+	{country: "South Korea", region: "KR", code: "82", minDigits: 8, maxDigits: 10, trunk: "0"},
+	{country: "Australia", region: "AU", code: "61", minDigits: 9, maxDigits: 9, trunk: "0"},
+	{country: "New Zealand", region: "NZ", code: "64", minDigits: 8, maxDigits: 10, trunk: "0"},
+	{country: "Brazil", region: "BR", code: "55", minDigits: 10, maxDigits: 11, trunk: "0"},
+	{country: "Mexico", region: "MX", code: "52", minDigits: 10, maxDigits: 10},
+	{country: "Argentina", region: "AR", code: "54", minDigits: 10, maxDigits: 11, trunk: "0"},
+	{country: "Chile", region: "CL", code: "56", minDigits: 9, maxDigits: 9},
+	{country: "Colombia", region: "CO", code: "57", minDigits: 10, maxDigits: 10},
+	{country: "Peru", region: "PE", code: "51", minDigits: 8, maxDigits: 9, trunk: "0"},
+}
+
+// phoneCountryByCode and phoneCountryByName index phoneCountries by calling
+// code, keeping the first country listed for a shared code, and by country
+// name as reported in locations
+var phoneCountryByCode, phoneCountryByName = func() (map[string]phoneCountry, map[string]phoneCountry) {
+	byCode := make(map[string]phoneCountry)
+	byName := make(map[string]phoneCountry)
+	for _, country := range phoneCountries {
+		if _, ok := byCode[country.code]; !ok {
+			byCode[country.code] = country
+		}
+		byName[country.country] = country
+	}
+	return byCode, byName
+}()
+
+// phoneNumber is a phone number as written in the resume and, when its
+// country could be worked out, in E.164 form
+type phoneNumber struct {
+	Raw    string
+	E164   string
+	Region string
+}
+
+// extractPhone finds the candidate's phone number, looking in the contact
+// lines before the rest of the text. country is where the candidate is
+// based, if known, and is assumed for numbers written without a calling
+// code that could be from more than one country.
+func extractPhone(text, header, country string) (phoneNumber, bool) {
+	if number, ok := findPhone(header, country, true); ok {
+		return number, true
+	}
+	return findPhone(text, country, false)
+}
+
+// findPhone returns the first phone number in text. Outside the contact
+// lines a number must be labelled as one or have a recognisable country,
+// so dates, IDs and other figures are not taken for it.
+func findPhone(text, country string, contact bool) (phoneNumber, bool) {
+	for _, loc := range phoneCandidateRegex.FindAllStringIndex(text, -1) {
+		raw := strings.TrimSpace(strings.TrimRight(text[loc[0]:loc[1]], " \t(.-/"))
+		if loc[0] > 0 && isPhoneNeighbour(text[loc[0]-1]) || loc[1] < len(text) && isPhoneNeighbour(text[loc[1]]) {
+			continue
+		}
+		// A hyphen joining digits to a word is part of a link slug or code
+		if loc[0] > 1 && text[loc[0]-1] == '-' && isPhoneNeighbour(text[loc[0]-2]) {
+			continue
+		}
+
+		lineStart := strings.LastIndex(text[:loc[0]], "\n") + 1
+		before := text[lineStart:loc[0]]
+		labelled := phoneLabelRegex.MatchString(before)
+		if !labelled && idLabelRegex.MatchString(before) {
+			continue
+		}
+
+		number, ok := parsePhone(raw, country)
+		if !ok {
+			continue
+		}
+		if number.E164 == "" && !labelled && !contact {
+			continue
+		}
+		return number, true
+	}
+	return phoneNumber{}, false
+}
+
+// isPhoneNeighbour reports whether a character next to a run of digits
+// makes it part of a word, link or larger figure rather than a phone number
+func isPhoneNeighbour(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '/' || c == '=' || c == '_' || c == '@' || c == '%' || c == '$'
+}
+
+// parsePhone reads a phone number written internationally, with "+" or
+// "00" before the calling code, or nationally. National numbers are
+// assigned the candidate's country when they fit it, and otherwise a
+// country is guessed from how they are written; numbers whose country
+// cannot be told are kept without an E.164 form.
+func parsePhone(raw, country string) (phoneNumber, bool) {
+	if yearRangeRegex.MatchString(raw) || dateRegex.MatchString(raw) {
+		return phoneNumber{}, false
+	}
+
+	groups := phoneGroupRegex.FindAllString(raw, -1)
+	digits := strings.Join(groups, "")
+	if len(digits) < 7 || len(digits) > 15 {
+		return phoneNumber{}, false
+	}
+	// A run of digits with no spacing needs to be a plausible length for a
+	// number written that way
+	if len(groups) == 1 && !strings.HasPrefix(raw, "+") && (len(digits) < 9 || len(digits) > 12) {
+		return phoneNumber{}, false
+	}
+	number := phoneNumber{Raw: raw}
+
+	if strings.HasPrefix(raw, "+") || strings.HasPrefix(raw, "(+") || strings.HasPrefix(digits, "00") && len(groups) > 1 {
+		digits = strings.TrimPrefix(digits, "00")
+		for n := 1; n <= 3 && n < len(digits); n++ {
+			candidate, ok := phoneCountryByCode[digits[:n]]
+			if !ok {
+				continue
+			}
+			// Prefer the candidate's country among those sharing the code
+			if home, ok := phoneCountryByName[country]; ok && home.code == candidate.code {
+				candidate = home
+			}
+			if national, ok := nationalNumber(candidate, digits[n:]); ok {
+				number.E164 = "+" + candidate.code + national
+				number.Region = candidate.region
+			}
+			return number, true
+		}
+		return number, true
+	}
+
+	candidates := guessPhoneCountries(raw, groups, digits)
+	if home, ok := phoneCountryByName[country]; ok {
+		candidates = append([]phoneCountry{home}, candidates...)
+	}
+	for _, candidate := range candidates {
+		if national, ok := nationalNumber(candidate, digits); ok {
+			number.E164 = "+" + candidate.code + national
+			number.Region = candidate.region
+			break
+		}
+	}
+	return number, true
+}
+
+// nationalNumber drops the trunk prefix from a national number and checks
+// its length. International numbers sometimes keep the trunk prefix, as in
+// "+44 (0)20 7946 0958", and mobiles are often written without it.
+func nationalNumber(country phoneCountry, digits string) (string, bool) {
+	fits := func(s string) bool {
+		return len(s) >= country.minDigits && len(s) <= country.maxDigits
+	}
+	if country.trunk != "" && strings.HasPrefix(digits, country.trunk) && fits(digits[len(country.trunk):]) {
+		return digits[len(country.trunk):], true
+	}
+	if fits(digits) {
+		return digits, true
+	}
+	return "", false
+}
+
+// guessPhoneCountries returns the countries a national number's digits and
+// grouping suggest, most likely first: "(555) 123-4567" is North American,
+// "07700 900123" British, "98765 43210" Indian and "0171/1234567" German
+func guessPhoneCountries(raw string, groups []string, digits string) []phoneCountry {
+	lengths := make([]int, len(groups))
+	for i, group := range groups {
+		lengths[i] = len(group)
+	}
+	endsWith := func(want ...int) bool {
+		if len(lengths) < len(want) {
+			return false
+		}
+		tail := lengths[len(lengths)-len(want):]
+		for i := range want {
+			if tail[i] != want[i] {
+				return false
+			}
+		}
+		return true
+	}
+
+	var guesses []string
+	switch {
+	case len(digits) == 11 && digits[0] == '1' && endsWith(3, 3, 4):
+		guesses = []string{"United States"}
+	case len(digits) == 10 && digits[0] >= '2' && endsWith(3, 3, 4):
+		guesses = []string{"United States"}
+	case len(digits) == 10 && digits[0] >= '6' && endsWith(5, 5):
+		guesses = []string{"India"}
+	case len(digits) == 11 && digits[0] == '0' && digits[1] >= '6' && endsWith(5, 5):
+		guesses = []string{"India"}
+	case digits[0] == '0' && strings.Contains(raw, "/"):
+		guesses = []string{"Germany"}
+	case len(digits) >= 11 && strings.HasPrefix(digits, "01") && strings.ContainsAny(digits[2:3], "567") && (len(digits) == 12 || endsWith(4, 7)):
+		guesses = []string{"Germany"}
+	case len(digits) == 11 && digits[0] == '0' && strings.ContainsAny(digits[1:2], "1237"):
+		guesses = []string{"United Kingdom"}
+	}
+
+	countries := make([]phoneCountry, 0, len(guesses))
+	for _, name := range guesses {
+		countries = append(countries, phoneCountryByName[name])
+	}
+	return countries
+}
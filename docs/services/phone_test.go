@@ -0,0 +1,129 @@
+package services
+
+import "testing"
+
+func TestParsePhone(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		country string
+		e164    string
+		region  string
+	}{
+		{"trunk zero kept after the code", "+44 (0)20 7946 0958", "", "+442079460958", "GB"},
+		{"bracketed code", "(+44) 7700 900123", "", "+447700900123", "GB"},
+		{"00 before the code", "0044 20 7946 0958", "", "+442079460958", "GB"},
+		{"British mobile", "07700 900123", "", "+447700900123", "GB"},
+		{"British landline", "020 7946 0958", "", "+442079460958", "GB"},
+		{"Indian international", "+91 98765 43210", "", "+919876543210", "IN"},
+		{"Indian mobile", "98765 43210", "", "+919876543210", "IN"},
+		{"Indian mobile with trunk zero", "0 98765 43210", "India", "+919876543210", "IN"},
+		{"German international", "+49 30 12345678", "", "+493012345678", "DE"},
+		{"German with slash", "0171/1234567", "", "+491711234567", "DE"},
+		{"German mobile", "0151 12345678", "", "+4915112345678", "DE"},
+		{"North American", "(555) 123-4567", "", "+15551234567", "US"},
+		{"North American with trunk", "1-800-555-0199", "", "+18005550199", "US"},
+		{"dotted North American", "415.555.0132", "", "+14155550132", "US"},
+		{"Canadian by location", "416-555-0123", "Canada", "+14165550123", "CA"},
+		{"Canadian international by location", "+1 416 555 0123", "Canada", "+14165550123", "CA"},
+		{"French by location", "06 12 34 56 78", "France", "+33612345678", "FR"},
+		{"unknown country", "555 0123 4567", "", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			number, ok := parsePhone(tt.raw, tt.country)
+			if !ok {
+				t.Fatalf("parsePhone(%q) found no number", tt.raw)
+			}
+			if number.Raw != tt.raw || number.E164 != tt.e164 || number.Region != tt.region {
+				t.Errorf("parsePhone(%q) = %+v, want %s %s", tt.raw, number, tt.e164, tt.region)
+			}
+		})
+	}
+}
+
+func TestParsePhoneRejects(t *testing.T) {
+	tests := []string{
+		"2019 - 2021",
+		"2019/2021",
+		"03/2018-2020",
+		"12/05/2021",
+		"2021.05.12",
+		"123-45-6789",
+		"12345678",
+		"1234567890123456",
+		"123 456",
+	}
+
+	for _, raw := range tests {
+		t.Run(raw, func(t *testing.T) {
+			if number, ok := parsePhone(raw, ""); ok {
+				t.Errorf("parsePhone(%q) = %+v, want no number", raw, number)
+			}
+		})
+	}
+}
+
+func TestExtractPhone(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		body   string
+		raw    string
+		e164   string
+	}{
+		{
+			name:   "contact line",
+			header: "Jane Doe\njane@example.com | +44 7700 900123",
+			raw:    "+44 7700 900123",
+			e164:   "+447700900123",
+		},
+		{
+			name:   "contact line before the body",
+			header: "Jane Doe | 020 7946 0958",
+			body:   "Phone: (555) 123-4567",
+			raw:    "020 7946 0958",
+			e164:   "+442079460958",
+		},
+		{
+			name:   "labelled in the body",
+			header: "Jane Doe",
+			body:   "References\nMobile: 555 0123 4567",
+			raw:    "555 0123 4567",
+		},
+		{
+			name:   "date ranges",
+			header: "Jane Doe",
+			body:   "Acme Corp 2019 - 2021\nGlobex 03/2016-2019",
+		},
+		{
+			name:   "IDs",
+			header: "Employee ID: 4820 1193 55\nLicence no. 0171 2345678",
+			body:   "Passport # 5550 1234 567",
+		},
+		{
+			name:   "SSN",
+			header: "Jane Doe\nSSN: 123 45 6789",
+			body:   "123-45-6789",
+		},
+		{
+			name:   "unlabelled figures in the body",
+			header: "Jane Doe",
+			body:   "Handled 1234 5678 requests and cut costs by 250 000",
+		},
+		{
+			name:   "part of a link",
+			header: "linkedin.com/in/jane-5551234567",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			number, ok := extractPhone(tt.header+"\n"+tt.body, tt.header, "")
+			if ok != (tt.raw != "") || number.Raw != tt.raw || number.E164 != tt.e164 {
+				t.Errorf("extractPhone = %+v %v, want %q %q", number, ok, tt.raw, tt.e164)
+			}
+		})
+	}
+}
@@ -0,0 +1,289 @@
+package services
+
+import (
+	"ats-analyzer/models"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var (
+	emailAddressRegex  = regexp.MustCompile(`[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}`)
+	profileURLRegex    = regexp.MustCompile(`(?i)(?:https?://)?(?:www\.)?(?:[a-z0-9](?:[a-z0-9-]*[a-z0-9])?\.)+[a-z]{2,}(?:/[^\s,;()<>"'|]*)?`)
+	locationLabelRegex = regexp.MustCompile(`(?i)^(?:location|address|based in|lives in|residence)\s*:?\s*`)
+	locationSplitRegex = regexp.MustCompile(`\s*(?:[|•·;\t]|\s[-–—]\s|\s{3,})\s*`)
+	postalCodeRegex    = regexp.MustCompile(`^\d{4,6}\s+|\s+(?:\d{4,6}(?:-\d{4})?|[A-Z]\d[A-Z]\s?\d[A-Z]\d|[A-Z]{1,2}\d[A-Z\d]?\s?\d[A-Z]{2})$`)
+	placeNameRegex     = regexp.MustCompile(`^\p{Lu}[\p{L}.'-]*(?:\s+(?:\p{Lu}[\p{L}.'-]*|de|da|del|do|la|le|upon|on|am))*$`)
+)
+
+// profileSite is a site candidates link their profile on. The username is
+// the first path segment after prefix.
+type profileSite struct {
+	domain string
+	kind   string
+	prefix string
+}
+
+// profileSites are matched against a link's host and its parent domains
+var profileSites = []profileSite{
+	{domain: "linkedin.com", kind: models.LinkLinkedIn, prefix: "/in/"},
+	{domain: "github.com", kind: models.LinkGitHub, prefix: "/"},
+	{domain: "gitlab.com", kind: models.LinkGitLab, prefix: "/"},
+	{domain: "stackoverflow.com", kind: models.LinkStackOverflow, prefix: "/users/"},
+	{domain: "bitbucket.org", kind: models.LinkBitbucket, prefix: "/"},
+	{domain: "twitter.com", kind: models.LinkTwitter, prefix: "/"},
+	{domain: "x.com", kind: models.LinkTwitter, prefix: "/"},
+	{domain: "kaggle.com", kind: models.LinkKaggle, prefix: "/"},
+	{domain: "medium.com", kind: models.LinkMedium, prefix: "/@"},
+	{domain: "behance.net", kind: models.LinkBehance, prefix: "/"},
+	{domain: "dribbble.com", kind: models.LinkDribbble, prefix: "/"},
+}
+
+// websiteTLDs are the domains a bare personal site address, written
+// without a scheme or www, is accepted from. Others are too easily
+// confused with names such as Node.js; skills named like a domain, such as
+// ASP.NET, are never a bare address.
+var websiteTLDs = map[string]bool{
+	"app": true, "blog": true, "co": true, "com": true, "design": true,
+	"dev": true, "info": true, "io": true, "me": true, "net": true,
+	"online": true, "org": true, "page": true, "site": true, "tech": true,
+	"xyz": true,
+}
+
+// extractProfileLinks finds links to the candidate's profiles anywhere in
+// the text, including hyperlink targets extractors keep beside their text.
+// Links to other sites count as the candidate's website only in the header,
+// where they are not a former employer's or a project's.
+func extractProfileLinks(text, header string) []models.ProfileLink {
+	var links []models.ProfileLink
+	seen := make(map[string]bool)
+
+	add := func(source string, websites bool) {
+		for _, loc := range profileURLRegex.FindAllStringIndex(source, -1) {
+			// Domains of email addresses, and names followed by one, are not links
+			if loc[0] > 0 && strings.ContainsAny(source[loc[0]-1:loc[0]], "@.") ||
+				loc[1] < len(source) && source[loc[1]] == '@' {
+				continue
+			}
+
+			link, ok := classifyProfileLink(source[loc[0]:loc[1]], websites)
+			if !ok || seen[strings.ToLower(link.URL)] {
+				continue
+			}
+			seen[strings.ToLower(link.URL)] = true
+			links = append(links, link)
+		}
+	}
+	add(header, true)
+	add(text, false)
+
+	return links
+}
+
+// stripLinks blanks out email addresses and links in text, so the sites and
+// usernames in them are not read as skills: github.com/jane is not GitHub
+// experience. Bare domains are only removed when they are among links, as
+// names such as Node.js look the same.
+func stripLinks(text string, links []models.ProfileLink) string {
+	blank := func(s string) string { return strings.Repeat(" ", len(s)) }
+	text = emailAddressRegex.ReplaceAllStringFunc(text, blank)
+
+	extracted := make(map[string]bool, len(links))
+	for _, link := range links {
+		extracted[strings.ToLower(link.URL)] = true
+	}
+	return profileURLRegex.ReplaceAllStringFunc(text, func(raw string) string {
+		lower := strings.ToLower(raw)
+		if strings.Contains(lower, "://") || strings.HasPrefix(lower, "www.") {
+			return blank(raw)
+		}
+		if link, _ := classifyProfileLink(raw, true); extracted[strings.ToLower(link.URL)] {
+			return blank(raw)
+		}
+		return raw
+	})
+}
+
+// classifyProfileLink works out what a link points to, normalising it to an
+// https URL without a trailing slash
+func classifyProfileLink(raw string, websites bool) (models.ProfileLink, bool) {
+	raw = strings.TrimRight(raw, ".:!?/")
+	explicit := strings.Contains(strings.ToLower(raw), "://") || strings.HasPrefix(strings.ToLower(raw), "www.")
+	if !strings.Contains(strings.ToLower(raw), "://") {
+		raw = "https://" + raw
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Host == "" {
+		return models.ProfileLink{}, false
+	}
+	host := strings.TrimPrefix(strings.ToLower(parsed.Host), "www.")
+	path := strings.TrimRight(parsed.EscapedPath(), "/")
+	link := models.ProfileLink{URL: "https://" + host + path}
+
+	for _, site := range profileSites {
+		if host != site.domain && !strings.HasSuffix(host, "."+site.domain) {
+			continue
+		}
+		// Company pages and the like are not the candidate's profile
+		if !strings.HasPrefix(path+"/", site.prefix) || len(path) <= len(site.prefix) {
+			return link, false
+		}
+		link.Type = site.kind
+		link.Username = strings.SplitN(strings.TrimPrefix(path, site.prefix), "/", 2)[0]
+		if site.kind == models.LinkStackOverflow {
+			// Profiles are /users/<id>/<name>
+			if parts := strings.Split(strings.TrimPrefix(path, site.prefix), "/"); len(parts) > 1 {
+				link.Username = parts[1]
+			}
+		}
+		return link, true
+	}
+
+	tld := host[strings.LastIndex(host, ".")+1:]
+	if !websites || !explicit && !websiteTLDs[tld] {
+		return link, false
+	}
+	if _, isSkill := DefaultSkillTaxonomy().Canonical(host); isSkill && !explicit {
+		return link, false
+	}
+	link.Type = models.LinkWebsite
+	return link, true
+}
+
+// extractLocation finds where the candidate is based from the contact
+// lines, returning the text as written and its parts. Locations need a
+// region or country beside the city, such as "Austin, TX" or "Lyon,
+// France", so a company or job title is not mistaken for a city.
+func extractLocation(header string) (string, models.Location, bool) {
+	for _, line := range strings.Split(header, "\n") {
+		for _, segment := range locationSplitRegex.Split(strings.TrimSpace(line), -1) {
+			segment = locationLabelRegex.ReplaceAllString(strings.TrimSpace(segment), "")
+			if strings.ContainsAny(segment, "@/") {
+				continue
+			}
+			if location, ok := parseLocation(segment); ok {
+				return segment, location, true
+			}
+		}
+	}
+	return "", models.Location{}, false
+}
+
+// parseLocation reads "City, Region", "City, Country" or "City, Region,
+// Country", ignoring a street address before them and postal codes
+func parseLocation(text string) (models.Location, bool) {
+	var parts []string
+	for _, part := range strings.Split(text, ",") {
+		if part = postalCodeRegex.ReplaceAllString(strings.TrimSpace(part), ""); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	if len(parts) < 2 {
+		return models.Location{}, false
+	}
+
+	var location models.Location
+	last := parts[len(parts)-1]
+	if country, ok := countryNames[strings.ToLower(last)]; ok {
+		location.Country = country
+		parts = parts[:len(parts)-1]
+		if len(parts) >= 2 && isPlaceName(parts[len(parts)-1]) && isPlaceName(parts[len(parts)-2]) {
+			location.Region = parts[len(parts)-1]
+			parts = parts[:len(parts)-1]
+		}
+	} else if country, ok := regionCountry(last); ok {
+		location.Region = last
+		location.Country = country
+		parts = parts[:len(parts)-1]
+	} else {
+		return models.Location{}, false
+	}
+
+	if len(parts) == 0 || !isPlaceName(parts[len(parts)-1]) {
+		return models.Location{}, false
+	}
+	location.City = parts[len(parts)-1]
+	return location, true
+}
+
+// regionCountry returns the country of a US state or Canadian province,
+// given by code or name
+func regionCountry(region string) (string, bool) {
+	if country, ok := regionCodes[region]; ok {
+		return country, true
+	}
+	if regionNames[strings.ToLower(region)] {
+		return "United States", true
+	}
+	return "", false
+}
+
+// isPlaceName reports whether text is capitalised like a place name, with
+// few enough words not to be a sentence
+func isPlaceName(text string) bool {
+	words := strings.Fields(text)
+	if len(words) == 0 || len(words) > 4 || !placeNameRegex.MatchString(text) {
+		return false
+	}
+	for _, r := range text {
+		if unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// regionCodes maps US state and Canadian province codes to their country
+var regionCodes = func() map[string]string {
+	codes := make(map[string]string)
+	for _, code := range strings.Fields("AL AK AZ AR CA CO CT DE DC FL GA HI ID IL IN IA KS KY LA ME MD MA MI MN MS MO MT NE NV NH NJ NM NY NC ND OH OK OR PA RI SC SD TN TX UT VT VA WA WV WI WY") {
+		codes[code] = "United States"
+	}
+	for _, code := range strings.Fields("AB BC MB NB NL NS NT NU ON PE QC SK YT") {
+		codes[code] = "Canada"
+	}
+	return codes
+}()
+
+// regionNames are the US states written out, lower case
+var regionNames = func() map[string]bool {
+	names := make(map[string]bool)
+	for _, name := range strings.Split("alabama,alaska,arizona,arkansas,california,colorado,connecticut,delaware,district of columbia,florida,georgia,hawaii,idaho,illinois,indiana,iowa,kansas,kentucky,louisiana,maine,maryland,massachusetts,michigan,minnesota,mississippi,missouri,montana,nebraska,nevada,new hampshire,new jersey,new mexico,new york,north carolina,north dakota,ohio,oklahoma,oregon,pennsylvania,rhode island,south carolina,south dakota,tennessee,texas,utah,vermont,virginia,washington,west virginia,wisconsin,wyoming", ",") {
+		names[name] = true
+	}
+	return names
+}()
+
+// countryNames maps lower-case country names and common abbreviations to
+// the name reported
+var countryNames = map[string]string{
+	"argentina": "Argentina", "australia": "Australia", "austria": "Austria",
+	"bangladesh": "Bangladesh", "belgium": "Belgium", "brazil": "Brazil",
+	"bulgaria": "Bulgaria", "canada": "Canada", "chile": "Chile", "china": "China",
+	"colombia": "Colombia", "croatia": "Croatia", "czech republic": "Czech Republic",
+	"czechia": "Czech Republic", "denmark": "Denmark", "egypt": "Egypt",
+	"estonia": "Estonia", "finland": "Finland", "france": "France",
+	"germany": "Germany", "ghana": "Ghana", "greece": "Greece",
+	"hong kong": "Hong Kong", "hungary": "Hungary", "india": "India",
+	"indonesia": "Indonesia", "ireland": "Ireland", "israel": "Israel",
+	"italy": "Italy", "japan": "Japan", "kenya": "Kenya", "latvia": "Latvia",
+	"lithuania": "Lithuania", "luxembourg": "Luxembourg", "malaysia": "Malaysia",
+	"mexico": "Mexico", "morocco": "Morocco", "netherlands": "Netherlands",
+	"the netherlands": "Netherlands", "new zealand": "New Zealand",
+	"nigeria": "Nigeria", "norway": "Norway", "pakistan": "Pakistan",
+	"peru": "Peru", "philippines": "Philippines", "poland": "Poland",
+	"portugal": "Portugal", "romania": "Romania", "russia": "Russia",
+	"saudi arabia": "Saudi Arabia", "serbia": "Serbia", "singapore": "Singapore",
+	"slovakia": "Slovakia", "slovenia": "Slovenia", "south africa": "South Africa",
+	"south korea": "South Korea", "korea": "South Korea", "spain": "Spain",
+	"sri lanka": "Sri Lanka", "sweden": "Sweden", "switzerland": "Switzerland",
+	"taiwan": "Taiwan", "thailand": "Thailand", "turkey": "Turkey",
+	"ukraine": "Ukraine", "united arab emirates": "United Arab Emirates",
+	"uae": "United Arab Emirates", "united kingdom": "United Kingdom",
+	"uk": "United Kingdom", "england": "United Kingdom", "scotland": "United Kingdom",
+	"wales": "United Kingdom", "united states": "United States",
+	"united states of america": "United States", "usa": "United States",
+	"us": "United States", "vietnam": "Vietnam",
+}
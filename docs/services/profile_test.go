@@ -0,0 +1,120 @@
+package services
+
+import (
+	"ats-analyzer/models"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestExtractProfileLinks(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		body   string
+		links  []models.ProfileLink
+	}{
+		{
+			name:   "bare profile",
+			header: "Jane Doe | linkedin.com/in/janedoe",
+			links:  []models.ProfileLink{{Type: models.LinkLinkedIn, URL: "https://linkedin.com/in/janedoe", Username: "janedoe"}},
+		},
+		{
+			name:   "full URLs",
+			header: "https://www.linkedin.com/in/jane-doe-123/ · http://github.com/janedoe/",
+			links: []models.ProfileLink{
+				{Type: models.LinkLinkedIn, URL: "https://linkedin.com/in/jane-doe-123", Username: "jane-doe-123"},
+				{Type: models.LinkGitHub, URL: "https://github.com/janedoe", Username: "janedoe"},
+			},
+		},
+		{
+			name:   "profile in the body",
+			header: "Jane Doe",
+			body:   "Maintainer of github.com/janedoe/scorer",
+			links:  []models.ProfileLink{{Type: models.LinkGitHub, URL: "https://github.com/janedoe/scorer", Username: "janedoe"}},
+		},
+		{
+			name:   "company pages",
+			header: "linkedin.com/company/acme | github.com",
+		},
+		{
+			name:   "portfolio domain",
+			header: "Jane Doe | janedoe.dev",
+			links:  []models.ProfileLink{{Type: models.LinkWebsite, URL: "https://janedoe.dev"}},
+		},
+		{
+			name:   "email domain",
+			header: "jane@janedoe.dev | jane.doe@example.com",
+		},
+		{
+			name:   "technology names",
+			header: "Node.js and ASP.NET developer",
+		},
+		{
+			name:   "website outside the header",
+			header: "Jane Doe",
+			body:   "Built the checkout for acme.com",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			links := extractProfileLinks(tt.header+"\n"+tt.body, tt.header)
+			if len(links) != len(tt.links) {
+				t.Fatalf("links = %+v, want %+v", links, tt.links)
+			}
+			for i, want := range tt.links {
+				if links[i] != want {
+					t.Errorf("link %d = %+v, want %+v", i, links[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestExtractLocation(t *testing.T) {
+	tests := []struct {
+		header   string
+		address  string
+		location models.Location
+	}{
+		{"Jane Doe\nAustin, TX | jane@example.com", "Austin, TX", models.Location{City: "Austin", Region: "TX", Country: "United States"}},
+		{"Location: Portland, Oregon", "Portland, Oregon", models.Location{City: "Portland", Region: "Oregon", Country: "United States"}},
+		{"Toronto, ON M5V 2T6", "Toronto, ON M5V 2T6", models.Location{City: "Toronto", Region: "ON", Country: "Canada"}},
+		{"Lyon, France · +33 6 12 34 56 78", "Lyon, France", models.Location{City: "Lyon", Country: "France"}},
+		{"12 Baker Street, London, United Kingdom", "12 Baker Street, London, United Kingdom", models.Location{City: "London", Country: "United Kingdom"}},
+		{"Munich, Bavaria, Germany", "Munich, Bavaria, Germany", models.Location{City: "Munich", Region: "Bavaria", Country: "Germany"}},
+		{"Senior Engineer, Acme", "", models.Location{}},
+		{"Python, Go, SQL", "", models.Location{}},
+		{"Jane Doe, PhD", "", models.Location{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			address, location, ok := extractLocation(tt.header)
+			if ok != (tt.address != "") || address != tt.address || location != tt.location {
+				t.Errorf("extractLocation = %q %+v %v, want %q %+v", address, location, ok, tt.address, tt.location)
+			}
+		})
+	}
+}
+
+func TestProfileLinksAreNotSkills(t *testing.T) {
+	text := `Jane Doe
+jane@gitlab.io | github.com/janedoe | https://bitbucket.org/janedoe | janedoe.dev
+Skills
+Python, Node.js, Docker`
+
+	resume, err := NewParser().ParseResumeFrom("resume.txt", strings.NewReader(text), int64(len(text)), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(resume.Skills)
+	// JavaScript is implied by Node.js; nothing comes from the links
+	if got := strings.Join(resume.Skills, ","); got != "docker,javascript,node.js,python" {
+		t.Errorf("skills = %s, want docker,javascript,node.js,python", got)
+	}
+	if len(resume.PersonalInfo.Links) != 3 {
+		t.Errorf("links = %+v, want GitHub, Bitbucket and the website", resume.PersonalInfo.Links)
+	}
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// defaultProfilesJSON holds the scoring profiles shipped with the binary
+//
+//go:embed scoring_profiles.json
+var defaultProfilesJSON []byte
+
+// ScoringProfilesEnv names the environment variable that overrides the
+// built-in scoring profiles with a file on disk
+const ScoringProfilesEnv = "SCORING_PROFILES_PATH"
+
+var (
+	defaultProfiles     *ScoringProfiles
+	defaultProfilesOnce sync.Once
+)
+
+// ScoringProfile is a named set of scoring weights
+type ScoringProfile struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Weights     ScoringWeights `json:"weights"`
+}
+
+// ScoringProfiles is the set of profiles a request can choose from
+type ScoringProfiles struct {
+	Default  string           `json:"default"`
+	Profiles []ScoringProfile `json:"profiles"`
+	byName   map[string]ScoringProfile
+}
+
+// DefaultScoringProfiles returns the process-wide profiles. They are loaded
+// from SCORING_PROFILES_PATH when set, otherwise from the embedded default.
+func DefaultScoringProfiles() *ScoringProfiles {
+	defaultProfilesOnce.Do(func() {
+		if path := os.Getenv(ScoringProfilesEnv); path != "" {
+			profiles, err := LoadScoringProfiles(path)
+			if err == nil {
+				defaultProfiles = profiles
+				return
+			}
+			logrus.Errorf("Failed to load scoring profiles from %s, using built-in: %v", path, err)
+		}
+
+		profiles, err := ParseScoringProfiles(defaultProfilesJSON)
+		if err != nil {
+			panic(fmt.Sprintf("invalid built-in scoring profiles: %v", err))
+		}
+		defaultProfiles = profiles
+	})
+
+	return defaultProfiles
+}
+
+// LoadScoringProfiles reads scoring profiles from a JSON file
+func LoadScoringProfiles(filename string) (*ScoringProfiles, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return ParseScoringProfiles(data)
+}
+
+// ParseScoringProfiles decodes profiles from JSON and validates every
+// profile's weights
+func ParseScoringProfiles(data []byte) (*ScoringProfiles, error) {
+	var profiles ScoringProfiles
+	if err := json.Unmarshal(data, &profiles); err != nil {
+		return nil, fmt.Errorf("failed to decode scoring profiles: %v", err)
+	}
+
+	profiles.byName = make(map[string]ScoringProfile)
+	for _, profile := range profiles.Profiles {
+		if profile.Name == "" {
+			return nil, fmt.Errorf("scoring profile without a name")
+		}
+		if _, exists := profiles.byName[profile.Name]; exists {
+			return nil, fmt.Errorf("duplicate scoring profile: %s", profile.Name)
+		}
+		if err := profile.Weights.Validate(); err != nil {
+			return nil, fmt.Errorf("scoring profile %s: %v", profile.Name, err)
+		}
+		profiles.byName[profile.Name] = profile
+	}
+
+	if _, ok := profiles.byName[profiles.Default]; !ok {
+		return nil, fmt.Errorf("default scoring profile %q is not defined", profiles.Default)
+	}
+
+	return &profiles, nil
+}
+
+// Get returns the named profile, or the default profile when name is empty
+func (p *ScoringProfiles) Get(name string) (ScoringProfile, error) {
+	if name == "" {
+		name = p.Default
+	}
+
+	profile, ok := p.byName[name]
+	if !ok {
+		return ScoringProfile{}, fmt.Errorf("unknown scoring profile: %s", name)
+	}
+	return profile, nil
+}
+
+// Names returns the available profile names in sorted order
+func (p *ScoringProfiles) Names() []string {
+	names := make([]string, 0, len(p.byName))
+	for name := range p.byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Validate checks that weights are non-negative and sum to 1
+func (w ScoringWeights) Validate() error {
+	components := map[string]float64{
+		"skill":      w.SkillWeight,
+		"title":      w.TitleWeight,
+		"experience": w.ExperienceWeight,
+		"education":  w.EducationWeight,
+		"format":     w.FormatWeight,
+	}
+
+	var sum float64
+	for name, weight := range components {
+		if weight < 0 {
+			return fmt.Errorf("%s weight cannot be negative", name)
+		}
+		sum += weight
+	}
+
+	if math.Abs(sum-1) > 1e-6 {
+		return fmt.Errorf("weights must sum to 1 (got %.4f)", sum)
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBuiltinScoringProfiles(t *testing.T) {
+	profiles, err := ParseScoringProfiles(defaultProfilesJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"default", "new-grad", "senior-engineer"}; !reflect.DeepEqual(profiles.Names(), want) {
+		t.Errorf("Names() = %v, want %v", profiles.Names(), want)
+	}
+
+	for _, profile := range profiles.Profiles {
+		w := profile.Weights
+		if sum := w.SkillWeight + w.TitleWeight + w.ExperienceWeight + w.EducationWeight + w.FormatWeight; math.Abs(sum-1) > 1e-9 {
+			t.Errorf("%s weights sum to %v, want 1", profile.Name, sum)
+		}
+		if profile.Description == "" {
+			t.Errorf("%s has no description", profile.Name)
+		}
+	}
+
+	profile, err := profiles.Get("")
+	if err != nil || profile.Name != profiles.Default {
+		t.Errorf("Get(\"\") = %s, %v, want the default profile %s", profile.Name, err, profiles.Default)
+	}
+}
+
+func TestParseScoringProfilesRejectsInvalidProfiles(t *testing.T) {
+	profile := func(name, weights string) string {
+		return `{"name": "` + name + `", "weights": {` + weights + `}}`
+	}
+	const balanced = `"skill": 0.4, "title": 0.2, "experience": 0.2, "education": 0.1, "format": 0.1`
+
+	tests := []struct {
+		name     string
+		json     string
+		errorMsg string
+	}{
+		{"malformed", `{"default": "a", "profiles": [`, "failed to decode"},
+		{"weights over 1", `{"default": "a", "profiles": [` + profile("a", `"skill": 0.6, "title": 0.2, "experience": 0.2, "education": 0.1, "format": 0.1`) + `]}`, "must sum to 1"},
+		{"weights under 1", `{"default": "a", "profiles": [` + profile("a", `"skill": 0.4`) + `]}`, "must sum to 1"},
+		{"negative weight", `{"default": "a", "profiles": [` + profile("a", `"skill": 1.2, "format": -0.2`) + `]}`, "format weight cannot be negative"},
+		{"no name", `{"default": "a", "profiles": [` + profile("", balanced) + `]}`, "without a name"},
+		{"duplicate", `{"default": "a", "profiles": [` + profile("a", balanced) + `,` + profile("a", balanced) + `]}`, "duplicate scoring profile: a"},
+		{"unknown default", `{"default": "b", "profiles": [` + profile("a", balanced) + `]}`, `default scoring profile "b"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseScoringProfiles([]byte(tt.json))
+			if err == nil || !strings.Contains(err.Error(), tt.errorMsg) {
+				t.Errorf("err = %v, want %q", err, tt.errorMsg)
+			}
+		})
+	}
+}
+
+func TestLoadScoringProfiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "profiles.json")
+	data := `{"default": "skills-only", "profiles": [{"name": "skills-only", "weights": {"skill": 1}}]}`
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	profiles, err := LoadScoringProfiles(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profile, err := profiles.Get("skills-only"); err != nil || profile.Weights.SkillWeight != 1 {
+		t.Errorf("Get(skills-only) = %+v, %v", profile, err)
+	}
+
+	if _, err := LoadScoringProfiles(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("loading a missing file succeeded")
+	}
+}
+
+func TestUseProfile(t *testing.T) {
+	scorer := NewScorer()
+	if err := scorer.UseProfile("senior-engineer"); err != nil {
+		t.Fatal(err)
+	}
+	if scorer.profile.Name != "senior-engineer" {
+		t.Errorf("profile = %s, want senior-engineer", scorer.profile.Name)
+	}
+
+	err := scorer.UseProfile("intern")
+	if err == nil || !strings.Contains(err.Error(), "unknown scoring profile: intern") {
+		t.Errorf("err = %v, want an unknown profile error", err)
+	}
+	if scorer.profile.Name != "senior-engineer" {
+		t.Errorf("profile changed to %s by an unknown name", scorer.profile.Name)
+	}
+
+	if err := scorer.UseProfile(""); err != nil || scorer.profile.Name != DefaultScoringProfiles().Default {
+		t.Errorf("UseProfile(\"\") = %v, profile %s, want the default", err, scorer.profile.Name)
+	}
+}
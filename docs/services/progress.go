@@ -0,0 +1,95 @@
+package services
+
+import (
+	"ats-analyzer/models"
+	"sort"
+	"strings"
+)
+
+// Stages reported while a resume is analysed
+const (
+	StageUploaded         = "uploaded"
+	StageTextExtracted    = "text_extracted"
+	StageSectionsDetected = "sections_detected"
+	StageResumeParsed     = "resume_parsed"
+	StageJDParsed         = "jd_parsed"
+	StageScored           = "scored"
+	StageFailed           = "failed"
+	StageBatchProgress    = "batch_progress"
+)
+
+// stageProgress is how far a single analysis has got once a stage completes
+var stageProgress = map[string]float64{
+	StageUploaded:         10,
+	StageTextExtracted:    35,
+	StageSectionsDetected: 50,
+	StageResumeParsed:     65,
+	StageJDParsed:         80,
+	StageScored:           100,
+}
+
+// StageFunc receives an event each time an analysis stage completes. Batch
+// analysis calls it from several workers, so it must be safe for concurrent
+// use.
+type StageFunc func(event models.ProgressEvent)
+
+// StageEvent builds the event for a completed stage of a single analysis
+func StageEvent(stage string, data interface{}) models.ProgressEvent {
+	return models.ProgressEvent{
+		Stage:    stage,
+		Progress: stageProgress[stage],
+		Data:     data,
+	}
+}
+
+// ProgressStages adapts a job progress callback to receive stage events.
+// Events for individual files of a batch do not move the job's progress.
+func ProgressStages(progress ProgressFunc) StageFunc {
+	return func(event models.ProgressEvent) {
+		if event.FileName != "" {
+			return
+		}
+		progress(event.Stage, event.Progress)
+	}
+}
+
+// Emit sends a stage event when a callback is registered
+func (f StageFunc) Emit(stage string, data interface{}) {
+	if f != nil {
+		f(StageEvent(stage, data))
+	}
+}
+
+// textStats summarises extracted text for the text_extracted stage
+func textStats(text string) map[string]interface{} {
+	return map[string]interface{}{
+		"characters": len(text),
+		"words":      len(strings.Fields(text)),
+	}
+}
+
+// sectionNames lists the detected sections for the sections_detected stage
+func sectionNames(resume *models.Resume) map[string]interface{} {
+	names := make([]string, 0, len(resume.Sections))
+	for name := range resume.Sections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return map[string]interface{}{
+		"sections":  names,
+		"segmented": isSegmented(resume),
+	}
+}
+
+// resumeSummary is the partial result sent once a resume has been parsed,
+// leaving out the raw text and section bodies
+func resumeSummary(resume *models.Resume) map[string]interface{} {
+	return map[string]interface{}{
+		"personal_info":  resume.PersonalInfo,
+		"skills":         resume.Skills,
+		"experience":     resume.Experience,
+		"education":      resume.Education,
+		"certifications": resume.Certifications,
+	}
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"ats-analyzer/models"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Reasons a resume cannot be read the way an ATS would read it
+const (
+	ReasonScanned   = "scanned_document"
+	ReasonEncrypted = "encrypted_document"
+	ReasonNoText    = "no_text"
+	ReasonGarbled   = "garbled_text"
+)
+
+// minReadableWords is the fewest words an extraction needs before it is
+// treated as the resume's text rather than stray page furniture
+const minReadableWords = 15
+
+// maxGarbledShare is the largest share of an extraction's characters that
+// may be unreadable, as text from fonts without a character map is
+const maxGarbledShare = 0.3
+
+// UnreadableError is a resume that opened but has no text an ATS could
+// read, such as a scanned PDF. It is reported as a result with the reason
+// rather than scored.
+type UnreadableError struct {
+	Reason  string
+	Message string
+}
+
+func (e *UnreadableError) Error() string {
+	return e.Message
+}
+
+// unreadable builds an UnreadableError from a formatted message
+func unreadable(reason, format string, args ...interface{}) *UnreadableError {
+	return &UnreadableError{Reason: reason, Message: fmt.Sprintf(format, args...)}
+}
+
+// UnreadableResult returns the result reported for a resume that cannot be
+// read, or nil when err is not an UnreadableError
+func UnreadableResult(fileName string, err error) *models.UnreadableResult {
+	var unreadableErr *UnreadableError
+	if !errors.As(err, &unreadableErr) {
+		return nil
+	}
+	return &models.UnreadableResult{
+		Status:   models.StatusNotATSReadable,
+		Reason:   unreadableErr.Reason,
+		Message:  unreadableErr.Message,
+		FileName: fileName,
+	}
+}
+
+// checkReadable rejects an extraction with too little text to analyse. The
+// format issues tell a scanned document from one that is simply empty.
+func checkReadable(text string, issues []models.FormatIssue) error {
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	garbled := isGarbled(text)
+	if len(words) >= minReadableWords && !garbled {
+		return nil
+	}
+
+	if len(words) < minReadableWords && hasFormatIssue(issues, IssueImageOnlyPage) {
+		return unreadable(ReasonScanned,
+			"The resume is a scanned or image-only document with no text layer, so an ATS cannot read it. Export it as a text-based PDF or DOCX, or run it through OCR.")
+	}
+	if garbled {
+		return unreadable(ReasonGarbled,
+			"The resume's text extracts as unreadable characters, usually because its fonts have no character map, so an ATS would see gibberish. Export it again with standard fonts.")
+	}
+	if len(words) == 0 {
+		return unreadable(ReasonNoText, "No text could be extracted from the resume, so an ATS would see it as blank.")
+	}
+	return unreadable(ReasonNoText,
+		"Only %d words could be extracted from the resume, too few for an ATS to find your experience and skills. Check the file is not mostly images or outlined text.", len(words))
+}
+
+// isGarbled reports whether too much of the text is replacement, private-use
+// or control characters for an ATS to make sense of it
+func isGarbled(text string) bool {
+	total, bad := 0, 0
+	for _, r := range text {
+		if unicode.IsSpace(r) {
+			continue
+		}
+		total++
+		if r == unicode.ReplacementChar || unicode.Is(unicode.Co, r) || unicode.IsControl(r) {
+			bad++
+		}
+	}
+	return total > 0 && float64(bad) > float64(total)*maxGarbledShare
+}
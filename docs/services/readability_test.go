@@ -0,0 +1,74 @@
+package services
+
+import (
+	"ats-analyzer/models"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCheckReadable(t *testing.T) {
+	const resume = "Jane Doe, software engineer in London. Built payment services in Go and Python for eight years."
+	imageOnly := []models.FormatIssue{{Code: IssueImageOnlyPage, Severity: models.SeverityError}}
+
+	tests := []struct {
+		name   string
+		text   string
+		issues []models.FormatIssue
+		reason string
+	}{
+		{name: "resume", text: resume},
+		{name: "resume with images", text: resume, issues: imageOnly},
+		{name: "empty", text: "", reason: ReasonNoText},
+		{name: "whitespace and punctuation", text: " \n\n • — | \n", reason: ReasonNoText},
+		{name: "few words", text: "Jane Doe\nPage 1 of 1", reason: ReasonNoText},
+		{name: "scanned", text: "Page 1", issues: imageOnly, reason: ReasonScanned},
+		{name: "private-use glyphs", text: strings.Repeat("\ue001\ue002\ue003 ", 40), reason: ReasonGarbled},
+		{name: "replacement characters", text: resume + strings.Repeat(" \ufffd\ufffd\ufffd", 30), reason: ReasonGarbled},
+		{name: "control characters", text: resume + strings.Repeat("\x01\x02\x03\x04", 20), reason: ReasonGarbled},
+		{name: "some unreadable characters", text: resume + " \ufffd \ue001"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkReadable(tt.text, tt.issues)
+			if code := ErrorCode(err); code != tt.reason || err != nil && tt.reason == "" {
+				t.Errorf("err = %v (reason %q), want reason %q", err, code, tt.reason)
+			}
+		})
+	}
+}
+
+func TestUnreadableResumesAreNotScored(t *testing.T) {
+	tests := []struct {
+		name   string
+		file   string
+		text   string
+		reason string
+	}{
+		{"empty", "resume.txt", "", ReasonNoText},
+		{"blank", "resume.txt", "\n\n   \n", ReasonNoText},
+		{"garbled", "resume.txt", strings.Repeat("\ufffd\ufffd \ue000\ue001\ue002 ", 50), ReasonGarbled},
+		{"empty HTML", "resume.html", "<html><body><img src=\"cv.png\"></body></html>", ReasonNoText},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resume, err := NewParser().ParseResumeFrom(tt.file, strings.NewReader(tt.text), int64(len(tt.text)), nil)
+			if resume != nil {
+				t.Fatalf("parsed an unreadable resume: %+v", resume)
+			}
+			result := UnreadableResult(tt.file, err)
+			if result == nil {
+				t.Fatalf("err = %v, want an unreadable resume", err)
+			}
+			if result.Status != models.StatusNotATSReadable || result.Reason != tt.reason || result.FileName != tt.file || result.Message == "" {
+				t.Errorf("got %+v, want status %s and reason %s", result, models.StatusNotATSReadable, tt.reason)
+			}
+		})
+	}
+
+	if result := UnreadableResult("resume.pdf", errors.New("failed to extract text")); result != nil {
+		t.Errorf("other errors: got %+v, want nil", result)
+	}
+}
@@ -2,43 +2,88 @@ package services
 
 import (
         "ats-analyzer/models"
-        "ats-analyzer/utils"
         "strings"
 )
 
 // Scorer handles resume scoring and analysis
 type Scorer struct {
-        nlp *NLPService
+        nlp      *NLPService
+        taxonomy *SkillTaxonomy
+        skills   *SkillMatcher
+
+        // ExperienceOptions controls which roles count towards total experience
+        ExperienceOptions models.ExperienceOptions
+
+        profile ScoringProfile
 }
 
 // NewScorer creates a new scorer instance
 func NewScorer() *Scorer {
+        taxonomy := DefaultSkillTaxonomy()
         return &Scorer{
-                nlp: NewNLPService(),
+                nlp:               NewNLPService(),
+                taxonomy:          taxonomy,
+                skills:            NewSkillMatcher(taxonomy),
+                ExperienceOptions: models.DefaultExperienceOptions(),
+                profile:           defaultProfile(),
         }
 }
 
 // ScoringWeights defines the weights for different scoring components
 type ScoringWeights struct {
-        SkillWeight      float64
-        ExperienceWeight float64
-        EducationWeight  float64
-        FormatWeight     float64
+        SkillWeight      float64 `json:"skill"`
+        TitleWeight      float64 `json:"title"`
+        ExperienceWeight float64 `json:"experience"`
+        EducationWeight  float64 `json:"education"`
+        FormatWeight     float64 `json:"format"`
 }
 
-// DefaultWeights returns the default scoring weights
+// PreferredSkillWeight is how much a preferred skill counts towards the skill
+// match relative to a required skill
+const PreferredSkillWeight = 0.5
+
+// DefaultWeights returns the weights of the default scoring profile
 func DefaultWeights() ScoringWeights {
+        return defaultProfile().Weights
+}
+
+// defaultProfile returns the configured default scoring profile
+func defaultProfile() ScoringProfile {
+        profiles := DefaultScoringProfiles()
+        profile, _ := profiles.Get(profiles.Default)
+        return profile
+}
+
+// UseProfile switches the scorer to the named scoring profile. An empty name
+// selects the default profile.
+func (s *Scorer) UseProfile(name string) error {
+        profile, err := DefaultScoringProfiles().Get(name)
+        if err != nil {
+                return err
+        }
+        s.profile = profile
+        return nil
+}
+
+// WithoutTitle spreads the title weight over the other components, for
+// analysis without a job title to match
+func (w ScoringWeights) WithoutTitle() ScoringWeights {
+        rest := w.SkillWeight + w.ExperienceWeight + w.EducationWeight + w.FormatWeight
+        if rest == 0 {
+                return w
+        }
+        scale := (rest + w.TitleWeight) / rest
         return ScoringWeights{
-                SkillWeight:      0.4,
-                ExperienceWeight: 0.3,
-                EducationWeight:  0.2,
-                FormatWeight:     0.1,
+                SkillWeight:      w.SkillWeight * scale,
+                ExperienceWeight: w.ExperienceWeight * scale,
+                EducationWeight:  w.EducationWeight * scale,
+                FormatWeight:     w.FormatWeight * scale,
         }
 }
 
 // AnalyzeResumeStandalone analyzes resume without job description
 func (s *Scorer) AnalyzeResumeStandalone(resume *models.Resume) *models.AnalysisResult {
-        weights := DefaultWeights()
+        weights := s.profile.Weights.WithoutTitle()
 
         // Calculate standalone scores
         skillScore := s.calculateSkillScoreStandalone(resume)
@@ -59,20 +104,24 @@ func (s *Scorer) AnalyzeResumeStandalone(resume *models.Resume) *models.Analysis
         suggestions := s.generateStandaloneSuggestions(resume, formatScore)
 
         return &models.AnalysisResult{
+                Status: models.StatusScored,
                 Score: overallScore,
                 SkillMatch: models.SkillMatchResult{
-                        Percentage:    skillScore * 100,
-                        MatchedSkills: resume.Skills,
-                        MissingSkills: []string{},
-                        TotalRequired: len(resume.Skills),
-                        TotalMatched:  len(resume.Skills),
+                        Percentage:        skillScore * 100,
+                        MatchedSkills:     resume.Skills,
+                        MissingSkills:     []string{},
+                        TotalRequired:     len(resume.Skills),
+                        TotalMatched:      len(resume.Skills),
+                        MatchedByCategory: s.taxonomy.GroupByCategory(resume.Skills),
+                        MissingByCategory: map[string][]string{},
+                        TaxonomyVersion:   s.taxonomy.Version,
                 },
-                ExperienceMatch: models.ExperienceResult{
+                ExperienceMatch: s.experienceDetails(resume, models.ExperienceResult{
                         Score:            experienceScore,
                         YearsRequired:    0,
-                        YearsCandidate:   resume.CalculateExperienceYears(),
+                        YearsCandidate:   resume.CalculateExperienceYearsWith(s.ExperienceOptions),
                         MeetsRequirement: true,
-                },
+                }),
                 EducationMatch: models.EducationResult{
                         Score:                educationScore,
                         MatchedDegrees:       s.extractDegreeNames(resume.Education),
@@ -83,6 +132,7 @@ func (s *Scorer) AnalyzeResumeStandalone(resume *models.Resume) *models.Analysis
                 MatchedKeywords: resume.Skills,
                 Suggestions:     suggestions,
                 ScoreBreakdown: models.ScoreBreakdown{
+                        Profile:          s.profile.Name,
                         SkillWeight:      weights.SkillWeight,
                         ExperienceWeight: weights.ExperienceWeight,
                         EducationWeight:  weights.EducationWeight,
@@ -97,16 +147,25 @@ func (s *Scorer) AnalyzeResumeStandalone(resume *models.Resume) *models.Analysis
 
 // AnalyzeResume performs comprehensive resume analysis
 func (s *Scorer) AnalyzeResume(resume *models.Resume, jobDesc *models.JobDescription) *models.AnalysisResult {
-        weights := DefaultWeights()
+        weights := s.profile.Weights
 
         // Calculate individual scores
         skillMatch := s.calculateSkillMatch(resume, jobDesc)
+        keywordMatch := s.calculateKeywordMatch(resume, jobDesc, skillMatch)
+        titleMatch := s.calculateTitleMatch(resume, jobDesc)
+
+        // Without a recognisable job title there is nothing to match, so the
+        // title weight goes to the other components rather than scoring zero
+        if !isRecognizedTitle(titleMatch.NormalizedJobTitle) {
+                weights = weights.WithoutTitle()
+        }
         experienceMatch := s.calculateExperienceMatch(resume, jobDesc)
         educationMatch := s.calculateEducationMatch(resume, jobDesc)
         formatScore := s.calculateFormatScore(resume)
 
         // Calculate overall score
         overallScore := (skillMatch.Percentage/100)*weights.SkillWeight +
+                titleMatch.Score*weights.TitleWeight +
                 experienceMatch.Score*weights.ExperienceWeight +
                 educationMatch.Score*weights.EducationWeight +
                 formatScore.Score*weights.FormatWeight
@@ -115,23 +174,29 @@ func (s *Scorer) AnalyzeResume(resume *models.Resume, jobDesc *models.JobDescrip
         overallScore *= 100
 
         // Generate suggestions
-        suggestions := s.generateSuggestions(resume, jobDesc, skillMatch, experienceMatch, educationMatch, formatScore)
+        suggestions := s.generateSuggestions(resume, jobDesc, overallScore, skillMatch, titleMatch, experienceMatch, educationMatch, formatScore)
 
         return &models.AnalysisResult{
+                Status:          models.StatusScored,
                 Score:           overallScore,
                 SkillMatch:      skillMatch,
+                TitleMatch:      titleMatch,
                 ExperienceMatch: experienceMatch,
                 EducationMatch:  educationMatch,
                 FormatScore:     formatScore,
-                MissingKeywords: skillMatch.MissingSkills,
-                MatchedKeywords: skillMatch.MatchedSkills,
+                KeywordMatch:    keywordMatch,
+                MissingKeywords: keywordTerms(keywordMatch.Missing),
+                MatchedKeywords: keywordTerms(keywordMatch.Matched),
                 Suggestions:     suggestions,
                 ScoreBreakdown: models.ScoreBreakdown{
+                        Profile:          s.profile.Name,
                         SkillWeight:      weights.SkillWeight,
+                        TitleWeight:      weights.TitleWeight,
                         ExperienceWeight: weights.ExperienceWeight,
                         EducationWeight:  weights.EducationWeight,
                         FormatWeight:     weights.FormatWeight,
                         SkillScore:       skillMatch.Percentage,
+                        TitleScore:       titleMatch.Score * 100,
                         ExperienceScore:  experienceMatch.Score * 100,
                         EducationScore:   educationMatch.Score * 100,
                         FormatScore:      formatScore.Score * 100,
@@ -141,24 +206,46 @@ func (s *Scorer) AnalyzeResume(resume *models.Resume, jobDesc *models.JobDescrip
 
 // calculateSkillMatch calculates skill matching score
 func (s *Scorer) calculateSkillMatch(resume *models.Resume, jobDesc *models.JobDescription) models.SkillMatchResult {
-        // Combine required and preferred skills
-        allJobSkills := append(jobDesc.RequiredSkills, jobDesc.PreferredSkills...)
-        allJobSkills = utils.RemoveDuplicates(allJobSkills)
+        // Skills implied by what the candidate lists also count (React implies JavaScript)
+        candidateSkills := s.taxonomy.ExpandImplied(resume.Skills)
+
+        requiredPct, matchedRequired, missingRequired := s.nlp.CalculateSkillMatch(candidateSkills, jobDesc.RequiredSkills)
+        preferredPct, matchedPreferred, missingPreferred := s.nlp.CalculateSkillMatch(candidateSkills, jobDesc.PreferredSkills)
 
-        percentage, matched, missing := s.nlp.CalculateSkillMatch(resume.Skills, allJobSkills)
+        // Weighted match where a missing preferred skill costs less than a missing required one
+        totalWeight := float64(len(jobDesc.RequiredSkills)) + float64(len(jobDesc.PreferredSkills))*PreferredSkillWeight
+        matchedWeight := float64(len(matchedRequired)) + float64(len(matchedPreferred))*PreferredSkillWeight
+
+        percentage := 0.0
+        if totalWeight > 0 {
+                percentage = matchedWeight / totalWeight * 100
+        }
+
+        // Required skills first so suggestions surface them before preferred ones
+        matched := append(append([]string{}, matchedRequired...), matchedPreferred...)
+        missing := append(append([]string{}, missingRequired...), missingPreferred...)
 
         return models.SkillMatchResult{
-                Percentage:    percentage,
-                MatchedSkills: matched,
-                MissingSkills: missing,
-                TotalRequired: len(allJobSkills),
-                TotalMatched:  len(matched),
+                Percentage:          percentage,
+                MatchedSkills:       matched,
+                MissingSkills:       missing,
+                TotalRequired:       len(jobDesc.RequiredSkills) + len(jobDesc.PreferredSkills),
+                TotalMatched:        len(matched),
+                MatchedRequired:     matchedRequired,
+                MissingRequired:     missingRequired,
+                MatchedPreferred:    matchedPreferred,
+                MissingPreferred:    missingPreferred,
+                RequiredPercentage:  requiredPct,
+                PreferredPercentage: preferredPct,
+                MatchedByCategory:   s.taxonomy.GroupByCategory(matched),
+                MissingByCategory:   s.taxonomy.GroupByCategory(missing),
+                TaxonomyVersion:     s.taxonomy.Version,
         }
 }
 
 // calculateExperienceMatch calculates experience matching score
 func (s *Scorer) calculateExperienceMatch(resume *models.Resume, jobDesc *models.JobDescription) models.ExperienceResult {
-        candidateYears := resume.CalculateExperienceYears()
+        candidateYears := resume.CalculateExperienceYearsWith(s.ExperienceOptions)
         requiredYears := float64(jobDesc.MinExperience)
 
         var score float64
@@ -176,12 +263,109 @@ func (s *Scorer) calculateExperienceMatch(resume *models.Resume, jobDesc *models
                 }
         }
 
-        return models.ExperienceResult{
+        // Blend in per-skill requirements ("5+ years of Go") when the JD has them
+        requirements, requirementScore := s.evaluateExperienceRequirements(resume, jobDesc, candidateYears)
+        if requirementScore >= 0 {
+                score = (score + requirementScore) / 2
+        }
+
+        result := s.experienceDetails(resume, models.ExperienceResult{
                 Score:            score,
                 YearsRequired:    jobDesc.MinExperience,
                 YearsCandidate:   candidateYears,
                 MeetsRequirement: meetsRequirement,
+        })
+        result.Requirements = requirements
+        return result
+}
+
+// evaluateExperienceRequirements checks each per-skill or per-domain years
+// requirement against the candidate's roles that mention it. The returned
+// score is the weighted share of requirements met, or -1 when there are none.
+func (s *Scorer) evaluateExperienceRequirements(resume *models.Resume, jobDesc *models.JobDescription, totalYears float64) ([]models.RequirementResult, float64) {
+        var results []models.RequirementResult
+        var totalWeight, metWeight float64
+
+        for _, req := range jobDesc.ExperienceRequirements {
+                var candidateYears float64
+                switch {
+                case req.Skill != "":
+                        candidateYears = s.yearsMentioning(resume, req.Skill)
+                case req.Domain != "":
+                        candidateYears = s.yearsMentioning(resume, req.Domain)
+                default:
+                        // General requirements are already covered by the overall score
+                        candidateYears = totalYears
+                }
+
+                met := candidateYears >= float64(req.MinYears)
+                results = append(results, models.RequirementResult{
+                        Skill:          req.Skill,
+                        Domain:         req.Domain,
+                        MinYears:       req.MinYears,
+                        Required:       req.Required,
+                        CandidateYears: candidateYears,
+                        Met:            met,
+                })
+
+                if req.Skill == "" && req.Domain == "" {
+                        continue
+                }
+
+                weight := 1.0
+                if !req.Required {
+                        weight = PreferredSkillWeight
+                }
+                totalWeight += weight
+                if met {
+                        metWeight += weight
+                } else if req.MinYears > 0 {
+                        metWeight += weight * candidateYears / float64(req.MinYears)
+                }
+        }
+
+        if totalWeight == 0 {
+                return results, -1
         }
+        return results, metWeight / totalWeight
+}
+
+// experienceDetails adds the per-role and per-skill breakdown and employment gaps
+func (s *Scorer) experienceDetails(resume *models.Resume, result models.ExperienceResult) models.ExperienceResult {
+        result.RoleBreakdown = resume.RoleBreakdown(s.ExperienceOptions)
+        result.SkillYears = s.calculateSkillYears(resume)
+        result.Gaps = resume.EmploymentGaps(s.ExperienceOptions)
+        result.Options = s.ExperienceOptions
+        return result
+}
+
+// calculateSkillYears estimates years of use for each resume skill from the
+// counted roles that mention it, merging overlapping roles
+func (s *Scorer) calculateSkillYears(resume *models.Resume) map[string]float64 {
+        skillYears := make(map[string]float64)
+
+        for _, skill := range resume.Skills {
+                if years := s.yearsMentioning(resume, skill); years > 0 {
+                        skillYears[skill] = years
+                }
+        }
+
+        return skillYears
+}
+
+// yearsMentioning returns the merged years of counted roles whose title or
+// description mentions the skill or domain
+func (s *Scorer) yearsMentioning(resume *models.Resume, term string) float64 {
+        var roles []models.Experience
+        for _, exp := range resume.Experience {
+                if !s.ExperienceOptions.Counts(exp) {
+                        continue
+                }
+                if s.skills.Contains(exp.Position+"\n"+exp.Description, term) {
+                        roles = append(roles, exp)
+                }
+        }
+        return models.MergedExperienceYears(roles)
 }
 
 // calculateEducationMatch calculates education matching score
@@ -220,25 +404,38 @@ func (s *Scorer) calculateEducationMatch(resume *models.Resume, jobDesc *models.
         }
 }
 
+// formatPenalties is how much each issue lowers the format score, by severity
+var formatPenalties = map[string]float64{
+        models.SeverityError:   0.3,
+        models.SeverityWarning: 0.2,
+        models.SeverityInfo:    0.05,
+}
+
 // calculateFormatScore analyzes resume formatting for ATS compatibility
 func (s *Scorer) calculateFormatScore(resume *models.Resume) models.FormatResult {
-        issues := resume.FormatIssues
-        
+        issues := append([]models.FormatIssue(nil), resume.FormatIssues...)
+
         // Additional format checks
         additionalIssues := s.analyzeAdditionalFormatIssues(resume)
         issues = append(issues, additionalIssues...)
 
-        // Calculate score based on number of issues
+        // Reduce the score by each issue's penalty, minimum 0.3
         score := 1.0
-        if len(issues) > 0 {
-                // Reduce score by 0.2 for each issue, minimum 0.3
-                score = 1.0 - float64(len(issues))*0.2
-                if score < 0.3 {
-                        score = 0.3
+        errors, warnings := 0, 0
+        for _, issue := range issues {
+                score -= formatPenalties[issue.Severity]
+                switch issue.Severity {
+                case models.SeverityError:
+                        errors++
+                case models.SeverityWarning:
+                        warnings++
                 }
         }
+        if score < 0.3 {
+                score = 0.3
+        }
 
-        isATSFriendly := len(issues) <= 1 // Allow for one minor issue
+        isATSFriendly := errors == 0 && warnings <= 1 // Allow for one minor issue
 
         return models.FormatResult{
                 Score:         score,
@@ -248,16 +445,25 @@ func (s *Scorer) calculateFormatScore(resume *models.Resume) models.FormatResult
 }
 
 // analyzeAdditionalFormatIssues performs additional format analysis
-func (s *Scorer) analyzeAdditionalFormatIssues(resume *models.Resume) []string {
-        var issues []string
+func (s *Scorer) analyzeAdditionalFormatIssues(resume *models.Resume) []models.FormatIssue {
+        var issues []models.FormatIssue
         text := resume.RawText
+        addIssue := func(code, message string) {
+                issues = append(issues, models.FormatIssue{Code: code, Severity: models.SeverityWarning, Message: message})
+        }
 
         // Check for contact information
         if resume.PersonalInfo.Email == "" {
-                issues = append(issues, "Missing email address")
+                addIssue(IssueMissingEmail, "Missing email address")
         }
         if resume.PersonalInfo.Phone == "" {
-                issues = append(issues, "Missing phone number")
+                addIssue(IssueMissingPhone, "Missing phone number")
+        }
+        if !hasProfileLink(resume, models.LinkLinkedIn) {
+                issues = append(issues, models.FormatIssue{Code: IssueMissingLinkedIn, Severity: models.SeverityInfo, Message: "No LinkedIn profile link found"})
+        }
+        if resume.PersonalInfo.Location == (models.Location{}) {
+                issues = append(issues, models.FormatIssue{Code: IssueMissingLocation, Severity: models.SeverityInfo, Message: "No location found; recruiters often filter candidates by city or country"})
         }
 
         // Check for section organization
@@ -266,23 +472,33 @@ func (s *Scorer) analyzeAdditionalFormatIssues(resume *models.Resume) []string {
         hasSkills := len(resume.Skills) > 0
 
         if !hasExperience && !hasEducation {
-                issues = append(issues, "Missing key sections (experience or education)")
+                addIssue(IssueMissingSections, "Missing key sections (experience or education)")
         }
         if !hasSkills {
-                issues = append(issues, "No skills section identified")
+                addIssue(IssueMissingSkills, "No skills section identified")
         }
 
         // Check for excessive length (heuristic)
         if len(strings.Split(text, " ")) > 1000 {
-                issues = append(issues, "Resume may be too long (consider condensing)")
+                addIssue(IssueTooLong, "Resume may be too long (consider condensing)")
         }
 
         return issues
 }
 
+// hasProfileLink reports whether the resume links a profile of the given type
+func hasProfileLink(resume *models.Resume, linkType string) bool {
+        for _, link := range resume.PersonalInfo.Links {
+                if link.Type == linkType {
+                        return true
+                }
+        }
+        return false
+}
+
 // generateSuggestions creates actionable suggestions for resume improvement
-func (s *Scorer) generateSuggestions(resume *models.Resume, jobDesc *models.JobDescription, 
-        skillMatch models.SkillMatchResult, experienceMatch models.ExperienceResult,
+func (s *Scorer) generateSuggestions(resume *models.Resume, jobDesc *models.JobDescription, overallScore float64,
+        skillMatch models.SkillMatchResult, titleMatch models.TitleMatchResult, experienceMatch models.ExperienceResult,
         educationMatch models.EducationResult, formatScore models.FormatResult) []string {
         
         var suggestions []string
@@ -306,6 +522,11 @@ func (s *Scorer) generateSuggestions(resume *models.Resume, jobDesc *models.JobD
                 suggestions = append(suggestions, "Good skill match! Consider adding: "+strings.Join(skillMatch.MissingSkills[:maxSkills], ", "))
         }
 
+        // Title-related suggestions
+        if isRecognizedTitle(titleMatch.NormalizedJobTitle) && titleMatch.Score < 0.5 {
+                suggestions = append(suggestions, "Your job titles differ from \""+titleMatch.JobTitle+"\". If your roles were equivalent, use a headline or title wording that mirrors the posting.")
+        }
+
         // Experience-related suggestions
         if !experienceMatch.MeetsRequirement {
                 if experienceMatch.YearsCandidate < float64(experienceMatch.YearsRequired) {
@@ -320,26 +541,33 @@ func (s *Scorer) generateSuggestions(resume *models.Resume, jobDesc *models.JobD
 
         // Format-related suggestions
         for _, issue := range formatScore.Issues {
-                switch {
-                case strings.Contains(issue, "table"):
+                switch issue.Code {
+                case IssueTable:
                         suggestions = append(suggestions, "Avoid using tables - use bullet points and clear headings instead.")
-                case strings.Contains(issue, "column"):
+                case IssueMultiColumn:
                         suggestions = append(suggestions, "Use a single-column layout for better ATS readability.")
-                case strings.Contains(issue, "email"):
+                case IssueTextBox:
+                        suggestions = append(suggestions, "Move text out of text boxes into the main body of the document.")
+                case IssueContactInHeader:
+                        suggestions = append(suggestions, "Put your contact details in the body of the resume rather than the page header or footer.")
+                case IssueImageOnlyPage:
+                        suggestions = append(suggestions, "Export your resume as a text-based PDF rather than a scan or image.")
+                case IssueMissingEmail:
                         suggestions = append(suggestions, "Add your email address to the contact section.")
-                case strings.Contains(issue, "phone"):
+                case IssueMissingPhone:
                         suggestions = append(suggestions, "Include your phone number in the contact information.")
-                case strings.Contains(issue, "skills"):
+                case IssueMissingLinkedIn:
+                        suggestions = append(suggestions, "Add a link to your LinkedIn profile next to your contact details.")
+                case IssueMissingLocation:
+                        suggestions = append(suggestions, "Add your city and country (or state) to your contact details.")
+                case IssueMissingSkills:
                         suggestions = append(suggestions, "Add a clear skills section with relevant technical and soft skills.")
-                case strings.Contains(issue, "too long"):
+                case IssueTooLong:
                         suggestions = append(suggestions, "Consider condensing your resume to 1-2 pages for better readability.")
                 }
         }
 
         // General suggestions based on overall score
-        overallScore := (skillMatch.Percentage/100)*0.4 + experienceMatch.Score*0.3 + educationMatch.Score*0.2 + formatScore.Score*0.1
-        overallScore *= 100
-
         if overallScore < 60 {
                 suggestions = append(suggestions, "Consider tailoring your resume more closely to this specific job description.")
         }
@@ -413,7 +641,7 @@ func (s *Scorer) calculateSkillScoreStandalone(resume *models.Resume) float64 {
 
 // calculateExperienceScoreStandalone calculates experience score without job description
 func (s *Scorer) calculateExperienceScoreStandalone(resume *models.Resume) float64 {
-        years := resume.CalculateExperienceYears()
+        years := resume.CalculateExperienceYearsWith(s.ExperienceOptions)
         
         if years == 0 {
                 return 0.0
@@ -474,7 +702,7 @@ func (s *Scorer) generateStandaloneSuggestions(resume *models.Resume, formatScor
         }
         
         // Experience suggestions
-        years := resume.CalculateExperienceYears()
+        years := resume.CalculateExperienceYearsWith(s.ExperienceOptions)
         if years < 1 {
                 suggestions = append(suggestions, "Include internships, projects, or volunteer work to demonstrate experience.")
         }
@@ -494,12 +722,22 @@ func (s *Scorer) generateStandaloneSuggestions(resume *models.Resume, formatScor
         
         // Format suggestions
         for _, issue := range formatScore.Issues {
-                switch {
-                case strings.Contains(issue, "table"):
+                switch issue.Code {
+                case IssueTable:
                         suggestions = append(suggestions, "Avoid using tables - use bullet points and clear headings instead.")
-                case strings.Contains(issue, "column"):
+                case IssueMultiColumn:
                         suggestions = append(suggestions, "Use a single-column layout for better ATS readability.")
-                case strings.Contains(issue, "too long"):
+                case IssueTextBox:
+                        suggestions = append(suggestions, "Move text out of text boxes into the main body of the document.")
+                case IssueContactInHeader:
+                        suggestions = append(suggestions, "Put your contact details in the body of the resume rather than the page header or footer.")
+                case IssueImageOnlyPage:
+                        suggestions = append(suggestions, "Export your resume as a text-based PDF rather than a scan or image.")
+                case IssueMissingLinkedIn:
+                        suggestions = append(suggestions, "Add a link to your LinkedIn profile next to your contact details.")
+                case IssueMissingLocation:
+                        suggestions = append(suggestions, "Add your city and country (or state) to your contact details.")
+                case IssueTooLong:
                         suggestions = append(suggestions, "Consider condensing your resume to 1-2 pages for better readability.")
                 }
         }
@@ -0,0 +1,334 @@
+package services
+
+import (
+	"ats-analyzer/models"
+	"regexp"
+	"strings"
+)
+
+// sectionHeadings maps normalized heading text to canonical section names
+var sectionHeadings = map[string]string{
+	// Summary
+	"summary":              models.SectionSummary,
+	"professional summary": models.SectionSummary,
+	"career summary":       models.SectionSummary,
+	"executive summary":    models.SectionSummary,
+	"profile":              models.SectionSummary,
+	"professional profile": models.SectionSummary,
+	"career profile":       models.SectionSummary,
+	"objective":            models.SectionSummary,
+	"career objective":     models.SectionSummary,
+	"about me":             models.SectionSummary,
+	"about":                models.SectionSummary,
+
+	// Experience
+	"experience":                 models.SectionExperience,
+	"work experience":            models.SectionExperience,
+	"professional experience":    models.SectionExperience,
+	"relevant experience":        models.SectionExperience,
+	"employment":                 models.SectionExperience,
+	"employment history":         models.SectionExperience,
+	"work history":               models.SectionExperience,
+	"career history":             models.SectionExperience,
+	"professional background":    models.SectionExperience,
+	"experience and internships": models.SectionExperience,
+	"internships":                models.SectionExperience,
+
+	// Education
+	"education":                  models.SectionEducation,
+	"education and training":     models.SectionEducation,
+	"academic background":        models.SectionEducation,
+	"academic qualifications":    models.SectionEducation,
+	"educational background":     models.SectionEducation,
+	"educational qualifications": models.SectionEducation,
+	"qualifications":             models.SectionEducation,
+
+	// Skills
+	"skills":                  models.SectionSkills,
+	"technical skills":        models.SectionSkills,
+	"key skills":              models.SectionSkills,
+	"core skills":             models.SectionSkills,
+	"core competencies":       models.SectionSkills,
+	"competencies":            models.SectionSkills,
+	"skills and abilities":    models.SectionSkills,
+	"technologies":            models.SectionSkills,
+	"technical proficiencies": models.SectionSkills,
+	"tools and technologies":  models.SectionSkills,
+
+	// Projects
+	"projects":          models.SectionProjects,
+	"personal projects": models.SectionProjects,
+	"academic projects": models.SectionProjects,
+	"key projects":      models.SectionProjects,
+	"selected projects": models.SectionProjects,
+	"side projects":     models.SectionProjects,
+
+	// Certifications
+	"certifications":              models.SectionCertifications,
+	"certificates":                models.SectionCertifications,
+	"licenses and certifications": models.SectionCertifications,
+	"certifications and licenses": models.SectionCertifications,
+	"professional certifications": models.SectionCertifications,
+	"courses and certifications":  models.SectionCertifications,
+
+	// Awards
+	"awards":                  models.SectionAwards,
+	"honors":                  models.SectionAwards,
+	"honors and awards":       models.SectionAwards,
+	"awards and honors":       models.SectionAwards,
+	"achievements":            models.SectionAwards,
+	"awards and achievements": models.SectionAwards,
+
+	// Publications
+	"publications":              models.SectionPublications,
+	"research":                  models.SectionPublications,
+	"research and publications": models.SectionPublications,
+	"papers":                    models.SectionPublications,
+
+	// Volunteering
+	"volunteering":          models.SectionVolunteering,
+	"volunteer experience":  models.SectionVolunteering,
+	"volunteer work":        models.SectionVolunteering,
+	"community involvement": models.SectionVolunteering,
+	"community service":     models.SectionVolunteering,
+}
+
+var headingTrimRegex = regexp.MustCompile(`^[^a-z]+|[^a-z]+$`)
+
+// normalizeHeading lowercases a candidate heading line and strips
+// decoration such as bullets, trailing colons and ampersands
+func normalizeHeading(line string) string {
+	heading := strings.ToLower(strings.TrimSpace(line))
+	heading = strings.ReplaceAll(heading, "&", " and ")
+	heading = strings.ReplaceAll(heading, "/", " and ")
+	heading = headingTrimRegex.ReplaceAllString(heading, "")
+	return strings.Join(strings.Fields(heading), " ")
+}
+
+// detectHeading reports the canonical section a line introduces along with
+// any content that follows the heading on the same line ("Skills: Go, SQL")
+func detectHeading(line string) (string, string, bool) {
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" || len(trimmed) > 50 {
+		return "", "", false
+	}
+
+	if section, ok := sectionHeadings[normalizeHeading(trimmed)]; ok {
+		return section, "", true
+	}
+
+	// Inline heading followed by content
+	if idx := strings.Index(trimmed, ":"); idx > 0 {
+		if section, ok := sectionHeadings[normalizeHeading(trimmed[:idx])]; ok {
+			return section, strings.TrimSpace(trimmed[idx+1:]), true
+		}
+	}
+
+	return "", "", false
+}
+
+// segmentSections splits resume text into its sections keyed by canonical
+// section name. Text before the first heading is stored under the header
+// section. Repeated sections are concatenated.
+func (p *Parser) segmentSections(text string) map[string]string {
+	sections := make(map[string]*strings.Builder)
+	current := models.SectionHeader
+
+	appendLine := func(section, line string) {
+		builder, ok := sections[section]
+		if !ok {
+			builder = &strings.Builder{}
+			sections[section] = builder
+		}
+		builder.WriteString(line)
+		builder.WriteString("\n")
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		if section, rest, ok := detectHeading(line); ok {
+			current = section
+			if _, exists := sections[current]; !exists {
+				sections[current] = &strings.Builder{}
+			}
+			if rest != "" {
+				appendLine(current, rest)
+			}
+			continue
+		}
+		appendLine(current, line)
+	}
+
+	result := make(map[string]string, len(sections))
+	for name, builder := range sections {
+		result[name] = builder.String()
+	}
+
+	return result
+}
+
+// isSegmented reports whether any section headings were detected
+func isSegmented(resume *models.Resume) bool {
+	for name := range resume.Sections {
+		if name != models.SectionHeader {
+			return true
+		}
+	}
+	return false
+}
+
+// sectionText returns the text of the named section. Resumes without any
+// recognisable headings fall back to the full text so they still get parsed.
+func (p *Parser) sectionText(resume *models.Resume, section, text string) string {
+	if !isSegmented(resume) {
+		return text
+	}
+	return resume.Sections[section]
+}
+
+// Job description requirement blocks
+const (
+	jdBlockGeneral = iota
+	jdBlockRequired
+	jdBlockPreferred
+)
+
+// jdBlockHeadings maps normalized job description headings to the kind of
+// requirement block they introduce
+var jdBlockHeadings = map[string]int{
+	"requirements":              jdBlockRequired,
+	"required":                  jdBlockRequired,
+	"required skills":           jdBlockRequired,
+	"required qualifications":   jdBlockRequired,
+	"minimum qualifications":    jdBlockRequired,
+	"basic qualifications":      jdBlockRequired,
+	"qualifications":            jdBlockRequired,
+	"must have":                 jdBlockRequired,
+	"must haves":                jdBlockRequired,
+	"must-have":                 jdBlockRequired,
+	"must-haves":                jdBlockRequired,
+	"what you'll need":          jdBlockRequired,
+	"what you need":             jdBlockRequired,
+	"what we're looking for":    jdBlockRequired,
+	"what you'll bring":         jdBlockRequired,
+	"skills and experience":     jdBlockRequired,
+	"you have":                  jdBlockRequired,
+	"preferred":                 jdBlockPreferred,
+	"preferred skills":          jdBlockPreferred,
+	"preferred qualifications":  jdBlockPreferred,
+	"nice to have":              jdBlockPreferred,
+	"nice to haves":             jdBlockPreferred,
+	"nice-to-have":              jdBlockPreferred,
+	"nice-to-haves":             jdBlockPreferred,
+	"good to have":              jdBlockPreferred,
+	"bonus":                     jdBlockPreferred,
+	"bonus points":              jdBlockPreferred,
+	"bonus skills":              jdBlockPreferred,
+	"pluses":                    jdBlockPreferred,
+	"desirable":                 jdBlockPreferred,
+	"desired skills":            jdBlockPreferred,
+	"desired qualifications":    jdBlockPreferred,
+	"additional qualifications": jdBlockPreferred,
+	"responsibilities":          jdBlockGeneral,
+	"key responsibilities":      jdBlockGeneral,
+	"what you'll do":            jdBlockGeneral,
+	"about us":                  jdBlockGeneral,
+	"about the role":            jdBlockGeneral,
+	"about the company":         jdBlockGeneral,
+	"benefits":                  jdBlockGeneral,
+	"perks":                     jdBlockGeneral,
+	"what we offer":             jdBlockGeneral,
+}
+
+// preferredLineRegex flags a single requirement clause as optional even
+// when it sits inside a requirements block
+var preferredLineRegex = regexp.MustCompile(`(?i)\b(preferred|nice[\s-]to[\s-]have|good to have|is a plus|a big plus|bonus|desirable|ideally)\b`)
+
+// requiredClauseRegex marks a clause that states its own requirement, such
+// as "Go required" in "Go required, Kubernetes preferred"
+var requiredClauseRegex = regexp.MustCompile(`(?i)\b(required|requirement|must|mandatory|essential)\b`)
+
+// clauseSeparatorRegex splits a requirement line into candidate clauses.
+// Semicolons and contrasting words (the first group) always end a clause;
+// commas and "and" (the second) only end one that carries its own marker.
+var clauseSeparatorRegex = regexp.MustCompile(`(?i)(\s*;\s*|,?\s+(?:but|while|whereas)\s+)|(,\s*(?:and\s+)?|\s+and\s+)`)
+
+// detectJDHeading reports the requirement block a job description line
+// introduces, along with any content following the heading on the same line
+func detectJDHeading(line string) (int, string, bool) {
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" || len(trimmed) > 60 {
+		return jdBlockGeneral, "", false
+	}
+
+	if block, ok := jdBlockHeadings[normalizeHeading(trimmed)]; ok {
+		return block, "", true
+	}
+
+	if idx := strings.Index(trimmed, ":"); idx > 0 {
+		if block, ok := jdBlockHeadings[normalizeHeading(trimmed[:idx])]; ok {
+			return block, strings.TrimSpace(trimmed[idx+1:]), true
+		}
+	}
+
+	return jdBlockGeneral, "", false
+}
+
+// splitJDRequirements separates job description text into general,
+// required and preferred blocks
+func (p *Parser) splitJDRequirements(text string) (string, string, string) {
+	var general, required, preferred strings.Builder
+	current := jdBlockGeneral
+
+	for _, line := range strings.Split(text, "\n") {
+		block := current
+		content := line
+		if heading, rest, ok := detectJDHeading(line); ok {
+			current = heading
+			block = heading
+			content = rest
+		}
+
+		// "Go required, Kubernetes preferred" is classified clause by clause
+		for _, clause := range splitRequirementClauses(content) {
+			clauseBlock := block
+			if preferredLineRegex.MatchString(clause) {
+				clauseBlock = jdBlockPreferred
+			}
+
+			switch clauseBlock {
+			case jdBlockRequired:
+				required.WriteString(clause)
+				required.WriteString("\n")
+			case jdBlockPreferred:
+				preferred.WriteString(clause)
+				preferred.WriteString("\n")
+			default:
+				general.WriteString(clause)
+				general.WriteString("\n")
+			}
+		}
+	}
+
+	return general.String(), required.String(), preferred.String()
+}
+
+// splitRequirementClauses splits a line into the clauses that may differ in
+// whether they are required. A list without markers of its own, as in
+// "Python, Go and SQL preferred", stays one clause so the trailing marker
+// covers all of it.
+func splitRequirementClauses(line string) []string {
+	var clauses []string
+	start := 0
+	for _, sep := range clauseSeparatorRegex.FindAllStringSubmatchIndex(line, -1) {
+		clause := line[start:sep[0]]
+		hard := sep[2] >= 0
+		if hard || preferredLineRegex.MatchString(clause) || requiredClauseRegex.MatchString(clause) {
+			clauses = append(clauses, strings.TrimSpace(clause))
+			start = sep[1]
+		}
+	}
+	if start == 0 {
+		return []string{line}
+	}
+	return append(clauses, strings.TrimSpace(line[start:]))
+}
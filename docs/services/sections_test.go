@@ -0,0 +1,57 @@
+package services
+
+import (
+	"ats-analyzer/models"
+	"strings"
+	"testing"
+)
+
+func TestSplitRequirementClauses(t *testing.T) {
+	tests := []struct {
+		line    string
+		clauses []string
+	}{
+		{"Go required, Kubernetes preferred", []string{"Go required", "Kubernetes preferred"}},
+		{"Python, Go and SQL preferred", []string{"Python, Go and SQL preferred"}},
+		{"Python, Go required, Kubernetes is a plus", []string{"Python, Go required", "Kubernetes is a plus"}},
+		{"Must know Java and Docker nice to have", []string{"Must know Java", "Docker nice to have"}},
+		{"AWS; GCP ideally", []string{"AWS", "GCP ideally"}},
+		{"Strong SQL but Spark is a bonus", []string{"Strong SQL", "Spark is a bonus"}},
+		{"  - 5+ years of Java", []string{"  - 5+ years of Java"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			clauses := splitRequirementClauses(tt.line)
+			if strings.Join(clauses, "|") != strings.Join(tt.clauses, "|") {
+				t.Errorf("splitRequirementClauses(%q) = %q, want %q", tt.line, clauses, tt.clauses)
+			}
+		})
+	}
+}
+
+func TestExtractJDSkillsByClause(t *testing.T) {
+	tests := []struct {
+		text      string
+		required  []string
+		preferred []string
+	}{
+		{"Requirements:\n- Go required, Kubernetes preferred", []string{"go"}, []string{"kubernetes"}},
+		{"Requirements:\n- Python, Docker and Terraform preferred", nil, []string{"python", "docker", "terraform"}},
+		{"Requirements:\n- Java; AWS is a plus", []string{"java"}, []string{"aws"}},
+	}
+
+	parser := NewParser()
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			jd := &models.JobDescription{}
+			parser.extractJDSkills(jd, tt.text)
+			if strings.Join(jd.RequiredSkills, ",") != strings.Join(tt.required, ",") {
+				t.Errorf("required = %v, want %v", jd.RequiredSkills, tt.required)
+			}
+			if strings.Join(jd.PreferredSkills, ",") != strings.Join(tt.preferred, ",") {
+				t.Errorf("preferred = %v, want %v", jd.PreferredSkills, tt.preferred)
+			}
+		})
+	}
+}
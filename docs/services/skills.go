@@ -0,0 +1,266 @@
+package services
+
+import (
+	"ats-analyzer/models"
+	"ats-analyzer/utils"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SkillMatcher finds skill mentions in text while respecting word boundaries
+// and reports them by their canonical taxonomy name
+type SkillMatcher struct {
+	taxonomy *SkillTaxonomy
+}
+
+// NewSkillMatcher creates a matcher backed by the given taxonomy
+func NewSkillMatcher(taxonomy *SkillTaxonomy) *SkillMatcher {
+	return &SkillMatcher{
+		taxonomy: taxonomy,
+	}
+}
+
+// skillContextWords are words that show a neighbouring ambiguous term names
+// a technology, as in "Go developer", "C programming" or "Go required"
+var skillContextWords = map[string]bool{
+	"programming": true, "language": true, "languages": true, "developer": true,
+	"developers": true, "development": true, "engineer": true, "engineers": true,
+	"framework": true, "frameworks": true, "library": true, "libraries": true,
+	"coding": true, "stack": true, "backend": true, "frontend": true, "sdk": true,
+	"required": true, "preferred": true, "proficient": true, "proficiency": true,
+}
+
+// skillUsageWords introduce the technology something was built with, as in
+// "written in Go" or "using Express"
+var skillUsageWords = map[string]bool{
+	"in": true, "using": true, "with": true, "on": true,
+}
+
+// notSkillAfterRegex matches what follows a season ("Spring 2020") or an
+// initial ("R. Smith") rather than a technology
+var notSkillAfterRegex = regexp.MustCompile(`^(?:\s*,?\s*(?:19|20)\d{2}\b|\.\s*\p{Lu})`)
+
+// neighbourWords is how many words either side of an ambiguous match are
+// searched for another technology
+const neighbourWords = 3
+
+// skillText is text prepared for matching: lower-cased without moving byte
+// offsets, with the spans of its skills sections located
+type skillText struct {
+	text       string
+	lower      string
+	skillSpans [][2]int
+}
+
+// newSkillText prepares text for matching
+func newSkillText(text string) *skillText {
+	text = strings.ToValidUTF8(text, string(utf8.RuneError))
+	st := &skillText{
+		text:  text,
+		lower: lowerKeepOffsets(text),
+	}
+
+	section := ""
+	offset := 0
+	for _, line := range strings.SplitAfter(text, "\n") {
+		lineStart := offset
+		offset += len(line)
+
+		if heading, content, ok := detectHeading(line); ok {
+			section = heading
+			if content == "" {
+				continue
+			}
+		}
+		if section == models.SectionSkills {
+			st.skillSpans = append(st.skillSpans, [2]int{lineStart, offset})
+		}
+	}
+
+	return st
+}
+
+// inSkillsSection reports whether the byte at pos is in a skills section
+func (st *skillText) inSkillsSection(pos int) bool {
+	for _, span := range st.skillSpans {
+		if pos >= span[0] && pos < span[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// lowerKeepOffsets lower-cases text rune by rune. Runes whose lower-case
+// form has a different UTF-8 length, such as "İ", are left as they are so
+// that byte offsets in the result are valid in the original text.
+func lowerKeepOffsets(text string) string {
+	return strings.Map(func(r rune) rune {
+		lower := unicode.ToLower(r)
+		if utf8.RuneLen(lower) != utf8.RuneLen(r) {
+			return r
+		}
+		return lower
+	}, text)
+}
+
+// FindSkills returns the canonical name of every skill mentioned in text,
+// under its own name or any alias
+func (m *SkillMatcher) FindSkills(text string) []string {
+	st := newSkillText(text)
+
+	var found []string
+	for _, term := range m.taxonomy.terms {
+		if m.containsTerm(st, term.term) {
+			found = append(found, term.canonical)
+		}
+	}
+
+	return utils.RemoveDuplicates(found)
+}
+
+// Contains reports whether text mentions skill, or one of its aliases, as a
+// whole token
+func (m *SkillMatcher) Contains(text, skill string) bool {
+	st := newSkillText(text)
+	canonical, ok := m.taxonomy.Canonical(skill)
+	if !ok {
+		return m.containsTerm(st, strings.ToLower(skill))
+	}
+
+	for _, term := range m.taxonomy.terms {
+		if term.canonical == canonical && m.containsTerm(st, term.term) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsTerm scans every occurrence of term and accepts the first one
+// that sits on token boundaries. Terms that are also everyday words or
+// single letters ("go", "r") must be mentioned as a technology; each
+// occurrence is judged on its own context.
+func (m *SkillMatcher) containsTerm(st *skillText, term string) bool {
+	if term == "" {
+		return false
+	}
+
+	offset := 0
+	for {
+		idx := strings.Index(st.lower[offset:], term)
+		if idx < 0 {
+			return false
+		}
+		start := offset + idx
+		end := start + len(term)
+
+		if isTokenBoundary(st.lower, start, end, term) &&
+			(!m.taxonomy.ambiguous[term] || m.mentionsTechnology(st, start, end)) {
+			return true
+		}
+
+		offset = start + 1
+	}
+}
+
+// mentionsTechnology decides whether an ambiguous term at [start, end)
+// names the technology. In a skills section it always does. Elsewhere it
+// must be capitalised and either be an item of a list or have another
+// technology among its neighbouring words.
+func (m *SkillMatcher) mentionsTechnology(st *skillText, start, end int) bool {
+	if st.inSkillsSection(start) {
+		return true
+	}
+
+	r, _ := utf8.DecodeRuneInString(st.text[start:])
+	if !unicode.IsUpper(r) {
+		return false
+	}
+
+	return isListItem(st.text, start, end) || m.nearTechnology(st, start, end)
+}
+
+// isListItem reports whether the match at [start, end) is set off by a list
+// separator, as in "Python, Go" or "C/C++", or follows a bullet
+func isListItem(text string, start, end int) bool {
+	before := strings.TrimRight(text[:start], " \t")
+	if r, _ := utf8.DecodeLastRuneInString(before); strings.ContainsRune(",;:/|•·(", r) {
+		return true
+	}
+	line := before[strings.LastIndexByte(before, '\n')+1:]
+	if line == "-" || line == "*" {
+		return true
+	}
+	after := strings.TrimLeft(text[end:], " \t")
+	if r, _ := utf8.DecodeRuneInString(after); strings.ContainsRune(",;/|•·)", r) {
+		return true
+	}
+	return false
+}
+
+// nearTechnology reports whether one of the words around the match on its
+// line is an unambiguous skill or a word such as "developer", or the match
+// follows a word such as "using" and is not a season or an initial
+func (m *SkillMatcher) nearTechnology(st *skillText, start, end int) bool {
+	lower := st.lower
+	lineStart := strings.LastIndexByte(lower[:start], '\n') + 1
+	lineEnd := len(lower)
+	if i := strings.IndexByte(lower[end:], '\n'); i >= 0 {
+		lineEnd = end + i
+	}
+
+	before := strings.Fields(lower[lineStart:start])
+	if len(before) > neighbourWords {
+		before = before[len(before)-neighbourWords:]
+	}
+	after := strings.Fields(lower[end:lineEnd])
+	if len(after) > neighbourWords {
+		after = after[:neighbourWords]
+	}
+
+	if len(before) > 0 && skillUsageWords[before[len(before)-1]] && !notSkillAfterRegex.MatchString(st.text[end:lineEnd]) {
+		return true
+	}
+
+	for _, word := range append(before, after...) {
+		word = strings.Trim(word, ",;:()[]|/•·.'\"")
+		if skillContextWords[word] {
+			return true
+		}
+		if _, ok := m.taxonomy.Canonical(word); ok && !m.taxonomy.ambiguous[word] {
+			return true
+		}
+	}
+	return false
+}
+
+// isTokenBoundary checks that the match at [start, end) is not part of a
+// longer word. Boundaries are only enforced on sides where the skill itself
+// starts or ends with a letter or digit, so ".net" still matches "asp.net".
+func isTokenBoundary(text string, start, end int, skill string) bool {
+	first, _ := utf8.DecodeRuneInString(skill)
+	last, _ := utf8.DecodeLastRuneInString(skill)
+
+	if isWordRune(first) && start > 0 {
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		if isWordRune(before) {
+			return false
+		}
+	}
+
+	if isWordRune(last) && end < len(text) {
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		// "+", "#" and "&" extend a token, so "c" must not match "c++" or
+		// "c#" and "r" must not match "r&d"
+		if isWordRune(after) || after == '+' || after == '#' || after == '&' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestFindSkills(t *testing.T) {
+	tests := []struct {
+		text   string
+		skills []string
+	}{
+		{"Python, Go and PostgreSQL", []string{"go", "postgresql", "python"}},
+		{"Built services in Golang on k8s", []string{"go", "kubernetes"}},
+		{"Wrote C++ and C# services", []string{"c#", "c++"}},
+		{"ASP.NET and Node.js APIs", []string{".net", "node.js"}},
+		{"MySQL tuning", []string{"mysql"}},
+		{"GitHub Actions pipelines", []string{"github"}},
+		{"JavaScript and TypeScript", []string{"javascript", "typescript"}},
+		{"Spring Boot microservices", []string{"microservices", "spring"}},
+		{"Built microservices in Go", []string{"go", "microservices"}},
+		{"Wrote the scoring service in Go last year", []string{"go"}},
+		{"Senior Go developer", []string{"go"}},
+		{"Languages: C/C++, R", []string{"c", "c++", "r"}},
+		{"Skills\ngo, rust, docker", []string{"docker", "go", "rust"}},
+		{"Technologies\n• Swift\n• Express", []string{"express", "swift"}},
+		{"İstanbul office. Tools: Docker, Go", []string{"docker", "go"}},
+		{"Go required", []string{"go"}},
+		{"- Rust\n- Swift", []string{"rust", "swift"}},
+	}
+
+	matcher := NewSkillMatcher(DefaultSkillTaxonomy())
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got := matcher.FindSkills(tt.text)
+			sort.Strings(got)
+			if strings.Join(got, ",") != strings.Join(tt.skills, ",") {
+				t.Errorf("FindSkills(%q) = %v, want %v", tt.text, got, tt.skills)
+			}
+		})
+	}
+}
+
+// TestFindSkillsFalsePositives lists text that once matched a skill it does
+// not mention
+func TestFindSkillsFalsePositives(t *testing.T) {
+	tests := []struct {
+		text  string
+		skill string
+	}{
+		{"I like to go hiking at weekends", "go"},
+		{"Google Analytics reporting", "go"},
+		{"Managed the R&D budget", "r"},
+		{"Wrote C++ and C# services", "c"},
+		{"JavaScript front ends", "java"},
+		{"Reacting quickly to incidents", "react"},
+		{"Restaurant manager", "rest api"},
+		{"Expressed interest in data", "express"},
+		{"Completed in less than a week", "less"},
+		{"Enrolled for the spring semester", "spring"},
+		{"Digital marketing lead", "git"},
+		{"GitHub Actions pipelines", "git"},
+		{"MySQL and SQLite tuning", "sql"},
+		{"Complied with labour laws", "aws"},
+		{"Wrote a theatre revue", "vue"},
+		{"Mechanical engineering degree", "gin"},
+		{"Fragile supply chains", "agile"},
+		{"Built HTML emails", "machine learning"},
+		{"Add 5 ml of solvent", "machine learning"},
+		{"Its results and outputs", "typescript"},
+		{"Added a node to the graph", "node.js"},
+		{"Consulted the oracle of the team", "oracle"},
+		{"Installed guardrails and handrails", "ruby on rails"},
+		{"Ensured swift delivery", "swift"},
+		{"Filled a flask with water", "flask"},
+		{"Removed rust from the hull", "rust"},
+		{"A scrumptious lunch", "agile"},
+		{"A sassy reply", "sass"},
+		{"A Kafkaesque process", "kafka"},
+		{"Pythonic idioms", "python"},
+		{"Dockerized nothing", "docker"},
+		{"Spring semester abroad in Madrid", "spring"},
+		{"Graduated in Spring 2020", "spring"},
+		{"Reported to the C-suite", "c"},
+		{"Worked with R. Smith on the audit", "r"},
+		{"Go to market strategy lead", "go"},
+		{"Swift delivery of orders", "swift"},
+		{"Rest days were rare", "rest api"},
+		{"Less paperwork for the team", "less"},
+		{"Express delivery, next day", "express"},
+		{"İstanbul office: we go hiking, with docker stickers", "go"},
+		{"Skills\nPython\n\nExperience\nSpring semester tutor at the Go club", "go"},
+		{"Skills\nPython\n\nExperience\nSpring semester tutor at the Go club", "spring"},
+	}
+
+	matcher := NewSkillMatcher(DefaultSkillTaxonomy())
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			for _, found := range matcher.FindSkills(tt.text) {
+				if found == tt.skill {
+					t.Errorf("FindSkills(%q) found %q", tt.text, tt.skill)
+				}
+			}
+			if matcher.Contains(tt.text, tt.skill) {
+				t.Errorf("Contains(%q, %q) = true", tt.text, tt.skill)
+			}
+		})
+	}
+}
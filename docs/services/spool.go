@@ -0,0 +1,139 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// UploadSpoolEnv names the environment variable pointing at a directory
+// uploads are written to instead of being held in memory
+const UploadSpoolEnv = "UPLOAD_SPOOL_DIR"
+
+// spoolPattern names spooled files, with a prefix of this service's own
+// since the directory may be shared. It never includes the uploaded file's
+// name, which often carries the candidate's.
+const spoolPattern = "ats-analyzer-upload-*"
+
+// spoolStaleAge is how old a spooled file must be before CleanSpoolDir
+// removes it. Another process sharing the directory holds its uploads for
+// no longer than a request or a job takes.
+const spoolStaleAge = time.Hour
+
+// Upload is a resume file received for analysis. It is held in memory
+// unless UPLOAD_SPOOL_DIR is set, in which case it is spooled to a uniquely
+// named file there. Close releases it and removes any spooled copy.
+type Upload struct {
+	Name string
+	Size int64
+
+	reader    io.ReaderAt
+	file      *os.File
+	closeOnce sync.Once
+}
+
+// ReadUpload reads an uploaded file of at most maxSize bytes
+func ReadUpload(name string, r io.Reader, maxSize int64) (*Upload, error) {
+	if dir := os.Getenv(UploadSpoolEnv); dir != "" {
+		return spoolUpload(dir, name, r, maxSize)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxSize {
+		return nil, uploadError(CodeFileTooLarge, "file size too large (max: %d bytes)", maxSize)
+	}
+
+	return &Upload{Name: name, Size: int64(len(data)), reader: bytes.NewReader(data)}, nil
+}
+
+// spoolUpload copies an upload into the spool directory
+func spoolUpload(dir, name string, r io.Reader, maxSize int64) (*Upload, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create spool directory: %v", err)
+	}
+
+	file, err := os.CreateTemp(dir, spoolPattern+strings.ToLower(filepath.Ext(name)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to spool upload: %v", err)
+	}
+	upload := &Upload{Name: name, reader: file, file: file}
+
+	size, err := io.Copy(file, io.LimitReader(r, maxSize+1))
+	if err != nil {
+		upload.Close()
+		return nil, fmt.Errorf("failed to spool upload: %v", err)
+	}
+	if size > maxSize {
+		upload.Close()
+		return nil, uploadError(CodeFileTooLarge, "file size too large (max: %d bytes)", maxSize)
+	}
+
+	upload.Size = size
+	return upload, nil
+}
+
+// ReadAt reads the upload's content, so an Upload can be passed wherever
+// an io.ReaderAt is expected
+func (u *Upload) ReadAt(p []byte, off int64) (int, error) {
+	return u.reader.ReadAt(p, off)
+}
+
+// Close releases the upload, removing its spooled file. It is safe to call
+// more than once.
+func (u *Upload) Close() error {
+	var err error
+	u.closeOnce.Do(func() {
+		if u.file == nil {
+			u.reader = bytes.NewReader(nil)
+			return
+		}
+		u.file.Close()
+		if removeErr := os.Remove(u.file.Name()); removeErr != nil && !os.IsNotExist(removeErr) {
+			err = fmt.Errorf("failed to remove spooled upload: %v", removeErr)
+		}
+	})
+	return err
+}
+
+// CleanSpoolDir removes uploads a previous process left in the spool
+// directory, such as after a crash. Only this service's files older than
+// spoolStaleAge are removed, so the uploads of other processes using the
+// directory are left alone. It does nothing when no spool directory is
+// configured.
+func CleanSpoolDir() {
+	dir := os.Getenv(UploadSpoolEnv)
+	if dir == "" {
+		return
+	}
+
+	paths, err := filepath.Glob(filepath.Join(dir, spoolPattern))
+	if err != nil {
+		return
+	}
+	cutoff := time.Now().Add(-spoolStaleAge)
+	removed := 0
+	for _, path := range paths {
+		info, err := os.Lstat(path)
+		if err != nil || !info.Mode().IsRegular() || info.ModTime().After(cutoff) {
+			continue
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			logrus.Errorf("Failed to remove stale upload %s: %v", path, err)
+			continue
+		}
+		removed++
+	}
+	if removed > 0 {
+		logrus.Infof("Removed %d stale uploads from %s", removed, dir)
+	}
+}
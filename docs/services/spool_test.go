@@ -0,0 +1,61 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCleanSpoolDir(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(UploadSpoolEnv, dir)
+
+	old := time.Now().Add(-2 * spoolStaleAge)
+	files := map[string]bool{
+		"ats-analyzer-upload-stale.pdf": false,
+		"ats-analyzer-upload-fresh.pdf": true,
+		"upload-other-service.pdf":      true,
+		"notes.txt":                     true,
+	}
+	for name := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte("x"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if name != "ats-analyzer-upload-fresh.pdf" {
+			if err := os.Chtimes(path, old, old); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	CleanSpoolDir()
+
+	for name, kept := range files {
+		_, err := os.Stat(filepath.Join(dir, name))
+		if exists := err == nil; exists != kept {
+			t.Errorf("%s exists = %v, want %v", name, exists, kept)
+		}
+	}
+}
+
+func TestSpoolUploadRemovedOnClose(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(UploadSpoolEnv, dir)
+
+	upload, err := ReadUpload("Jane Doe.PDF", strings.NewReader("%PDF-1.4"), 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	paths, _ := filepath.Glob(filepath.Join(dir, spoolPattern))
+	if len(paths) != 1 || filepath.Ext(paths[0]) != ".pdf" || strings.Contains(paths[0], "Jane") {
+		t.Errorf("spooled files = %v, want one anonymous .pdf", paths)
+	}
+
+	upload.Close()
+	if paths, _ := filepath.Glob(filepath.Join(dir, "*")); len(paths) != 0 {
+		t.Errorf("files left after Close: %v", paths)
+	}
+}
@@ -0,0 +1,197 @@
+package services
+
+import (
+	"ats-analyzer/models"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// defaultTaxonomyJSON is the skill taxonomy shipped with the binary
+//
+//go:embed skill_taxonomy.json
+var defaultTaxonomyJSON []byte
+
+// SkillTaxonomyEnv names the environment variable that overrides the
+// built-in taxonomy with a file on disk
+const SkillTaxonomyEnv = "SKILL_TAXONOMY_PATH"
+
+var (
+	defaultTaxonomy     *SkillTaxonomy
+	defaultTaxonomyOnce sync.Once
+)
+
+// skillTerm maps a surface form found in text to its canonical skill
+type skillTerm struct {
+	term      string
+	canonical string
+}
+
+// SkillTaxonomy holds canonical skills, their aliases, categories and
+// implied parent skills
+type SkillTaxonomy struct {
+	Version   string
+	skills    map[string]models.SkillDefinition
+	order     []string
+	terms     []skillTerm
+	index     map[string]string
+	ambiguous map[string]bool
+}
+
+// DefaultSkillTaxonomy returns the process-wide taxonomy. It is loaded from
+// SKILL_TAXONOMY_PATH when set, otherwise from the embedded default.
+func DefaultSkillTaxonomy() *SkillTaxonomy {
+	defaultTaxonomyOnce.Do(func() {
+		if path := os.Getenv(SkillTaxonomyEnv); path != "" {
+			taxonomy, err := LoadSkillTaxonomy(path)
+			if err == nil {
+				defaultTaxonomy = taxonomy
+				return
+			}
+			logrus.Errorf("Failed to load skill taxonomy from %s, using built-in: %v", path, err)
+		}
+
+		taxonomy, err := ParseSkillTaxonomy(defaultTaxonomyJSON)
+		if err != nil {
+			panic(fmt.Sprintf("invalid built-in skill taxonomy: %v", err))
+		}
+		defaultTaxonomy = taxonomy
+	})
+
+	return defaultTaxonomy
+}
+
+// LoadSkillTaxonomy reads a taxonomy from a JSON file
+func LoadSkillTaxonomy(filename string) (*SkillTaxonomy, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSkillTaxonomy(data)
+}
+
+// ParseSkillTaxonomy builds a taxonomy from JSON and validates that names
+// and aliases are unique and every implied skill is defined
+func ParseSkillTaxonomy(data []byte) (*SkillTaxonomy, error) {
+	var file models.SkillTaxonomyFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to decode taxonomy: %v", err)
+	}
+
+	if file.Version == "" {
+		return nil, fmt.Errorf("taxonomy version is required")
+	}
+
+	taxonomy := &SkillTaxonomy{
+		Version:   file.Version,
+		skills:    make(map[string]models.SkillDefinition),
+		index:     make(map[string]string),
+		ambiguous: make(map[string]bool),
+	}
+
+	for _, def := range file.Skills {
+		name := strings.ToLower(strings.TrimSpace(def.Name))
+		if name == "" {
+			return nil, fmt.Errorf("taxonomy contains a skill without a name")
+		}
+		if _, exists := taxonomy.skills[name]; exists {
+			return nil, fmt.Errorf("duplicate skill: %s", name)
+		}
+		def.Name = name
+		if def.Category == "" {
+			def.Category = "other"
+		}
+		taxonomy.skills[name] = def
+		taxonomy.order = append(taxonomy.order, name)
+
+		for _, term := range append([]string{name}, def.Aliases...) {
+			term = strings.ToLower(strings.TrimSpace(term))
+			if term == "" {
+				continue
+			}
+			if owner, exists := taxonomy.index[term]; exists {
+				return nil, fmt.Errorf("term %q is used by both %s and %s", term, owner, name)
+			}
+			taxonomy.index[term] = name
+			taxonomy.terms = append(taxonomy.terms, skillTerm{term: term, canonical: name})
+		}
+	}
+
+	for _, def := range taxonomy.skills {
+		for _, parent := range def.Implies {
+			if _, ok := taxonomy.skills[strings.ToLower(parent)]; !ok {
+				return nil, fmt.Errorf("skill %s implies unknown skill %s", def.Name, parent)
+			}
+		}
+	}
+
+	for _, term := range file.AmbiguousTerms {
+		taxonomy.ambiguous[strings.ToLower(term)] = true
+	}
+
+	return taxonomy, nil
+}
+
+// Canonical returns the canonical skill name for a skill or alias
+func (t *SkillTaxonomy) Canonical(term string) (string, bool) {
+	canonical, ok := t.index[strings.ToLower(strings.TrimSpace(term))]
+	return canonical, ok
+}
+
+// Category returns the category of a skill, or "other" when unknown
+func (t *SkillTaxonomy) Category(skill string) string {
+	if canonical, ok := t.Canonical(skill); ok {
+		return t.skills[canonical].Category
+	}
+	return "other"
+}
+
+// Skills returns all canonical skill names in taxonomy order
+func (t *SkillTaxonomy) Skills() []string {
+	return append([]string(nil), t.order...)
+}
+
+// ExpandImplied adds every skill implied by the given skills, transitively.
+// A candidate who knows React is taken to know JavaScript as well.
+func (t *SkillTaxonomy) ExpandImplied(skills []string) []string {
+	seen := make(map[string]bool)
+	var result []string
+
+	var visit func(skill string)
+	visit = func(skill string) {
+		canonical, ok := t.Canonical(skill)
+		if !ok {
+			canonical = strings.ToLower(skill)
+		}
+		if seen[canonical] {
+			return
+		}
+		seen[canonical] = true
+		result = append(result, canonical)
+
+		for _, parent := range t.skills[canonical].Implies {
+			visit(parent)
+		}
+	}
+
+	for _, skill := range skills {
+		visit(skill)
+	}
+
+	return result
+}
+
+// GroupByCategory buckets skills by their taxonomy category
+func (t *SkillTaxonomy) GroupByCategory(skills []string) map[string][]string {
+	groups := make(map[string][]string)
+	for _, skill := range skills {
+		category := t.Category(skill)
+		groups[category] = append(groups[category], skill)
+	}
+	return groups
+}
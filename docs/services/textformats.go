@@ -0,0 +1,481 @@
+package services
+
+import (
+	"ats-analyzer/models"
+	"bytes"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// txtExtractor reads plain-text resumes, detecting their encoding
+type txtExtractor struct{}
+
+// Format describes plain text
+func (txtExtractor) Format() models.DocumentFormat {
+	return models.DocumentFormat{
+		Name:       "TXT",
+		Extensions: []string{".txt"},
+		MIMETypes:  []string{"text/plain"},
+	}
+}
+
+// Detect matches the extension only, since any bytes may be text
+func (e txtExtractor) Detect(filename string, head []byte) bool {
+	return HasExtension(filename, e.Format().Extensions...)
+}
+
+// Validate rejects binary content
+func (e txtExtractor) Validate(r io.ReaderAt, size int64, limits UploadLimits) error {
+	return checkText(r, size, e.Format().Name)
+}
+
+// Extract reads the file one line per block
+func (txtExtractor) Extract(r io.ReaderAt, size int64) ([]models.TextBlock, error) {
+	data, err := readAll(r, size)
+	if err != nil {
+		return nil, err
+	}
+	return BlocksFromText(decodeText(data)), nil
+}
+
+// markdownExtractor reads Markdown resumes. Headings become heading blocks
+// so they still separate sections.
+type markdownExtractor struct{}
+
+// Format describes Markdown
+func (markdownExtractor) Format() models.DocumentFormat {
+	return models.DocumentFormat{
+		Name:       "Markdown",
+		Extensions: []string{".md", ".markdown"},
+		MIMETypes:  []string{"text/markdown"},
+	}
+}
+
+// Detect matches the extension only, since Markdown is plain text
+func (e markdownExtractor) Detect(filename string, head []byte) bool {
+	return HasExtension(filename, e.Format().Extensions...)
+}
+
+// Validate rejects binary content
+func (e markdownExtractor) Validate(r io.ReaderAt, size int64, limits UploadLimits) error {
+	return checkText(r, size, e.Format().Name)
+}
+
+// Extract strips the Markdown syntax from the file
+func (markdownExtractor) Extract(r io.ReaderAt, size int64) ([]models.TextBlock, error) {
+	data, err := readAll(r, size)
+	if err != nil {
+		return nil, err
+	}
+	return markdownToBlocks(decodeText(data)), nil
+}
+
+// rtfMagic starts every RTF document
+var rtfMagic = []byte(`{\rtf`)
+
+// rtfExtractor reads RTF resumes
+type rtfExtractor struct{}
+
+// Format describes RTF
+func (rtfExtractor) Format() models.DocumentFormat {
+	return models.DocumentFormat{
+		Name:       "RTF",
+		Extensions: []string{".rtf"},
+		MIMETypes:  []string{"application/rtf", "text/rtf"},
+	}
+}
+
+// Detect matches the extension or the {\rtf header
+func (e rtfExtractor) Detect(filename string, head []byte) bool {
+	return bytes.HasPrefix(head, rtfMagic) || HasExtension(filename, e.Format().Extensions...)
+}
+
+// Validate checks the {\rtf header
+func (rtfExtractor) Validate(r io.ReaderAt, size int64, limits UploadLimits) error {
+	head, err := readHead(r, size)
+	if err != nil {
+		return err
+	}
+	if !bytes.HasPrefix(head, rtfMagic) {
+		return uploadError(CodeFormatMismatch, "file is not an RTF document")
+	}
+	return nil
+}
+
+// Extract strips the RTF control words from the file
+func (rtfExtractor) Extract(r io.ReaderAt, size int64) ([]models.TextBlock, error) {
+	data, err := readAll(r, size)
+	if err != nil {
+		return nil, err
+	}
+	return BlocksFromText(rtfToText(data)), nil
+}
+
+// cp1252 maps the bytes 0x80-0x9F that Windows-1252 uses for printable
+// characters where Latin-1 has control codes
+var cp1252 = map[byte]rune{
+	0x80: '€', 0x82: '‚', 0x83: 'ƒ', 0x84: '„', 0x85: '…', 0x86: '†', 0x87: '‡',
+	0x88: 'ˆ', 0x89: '‰', 0x8A: 'Š', 0x8B: '‹', 0x8C: 'Œ', 0x8E: 'Ž',
+	0x91: '‘', 0x92: '’', 0x93: '“', 0x94: '”', 0x95: '•', 0x96: '–', 0x97: '—',
+	0x98: '˜', 0x99: '™', 0x9A: 'š', 0x9B: '›', 0x9C: 'œ', 0x9E: 'ž', 0x9F: 'Ÿ',
+}
+
+// decodeText converts file content to UTF-8. It recognises byte order
+// marks, UTF-16 without a BOM, valid UTF-8, and falls back to Windows-1252,
+// a superset of Latin-1.
+func decodeText(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xEF, 0xBB, 0xBF}):
+		return normalizeNewlines(string(data[3:]))
+	case bytes.HasPrefix(data, []byte{0xFF, 0xFE}):
+		return normalizeNewlines(decodeUTF16(data[2:], false))
+	case bytes.HasPrefix(data, []byte{0xFE, 0xFF}):
+		return normalizeNewlines(decodeUTF16(data[2:], true))
+	}
+
+	if bigEndian, ok := looksLikeUTF16(data); ok {
+		return normalizeNewlines(decodeUTF16(data, bigEndian))
+	}
+
+	if utf8.Valid(data) {
+		return normalizeNewlines(string(data))
+	}
+
+	return normalizeNewlines(decodeWindows1252(data))
+}
+
+// looksLikeUTF16 guesses whether BOM-less data is UTF-16 from the zero
+// bytes ASCII characters leave in every other position
+func looksLikeUTF16(data []byte) (bigEndian bool, ok bool) {
+	if len(data) < 4 || len(data)%2 != 0 {
+		return false, false
+	}
+
+	var evenZeros, oddZeros int
+	for i := 0; i+1 < len(data); i += 2 {
+		if data[i] == 0 {
+			evenZeros++
+		}
+		if data[i+1] == 0 {
+			oddZeros++
+		}
+	}
+
+	pairs := len(data) / 2
+	switch {
+	case oddZeros > pairs*3/10 && evenZeros*20 < pairs:
+		return false, true
+	case evenZeros > pairs*3/10 && oddZeros*20 < pairs:
+		return true, true
+	}
+	return false, false
+}
+
+// decodeUTF16 decodes UTF-16 data in the given byte order
+func decodeUTF16(data []byte, bigEndian bool) string {
+	units := make([]uint16, 0, len(data)/2)
+	for i := 0; i+1 < len(data); i += 2 {
+		if bigEndian {
+			units = append(units, uint16(data[i])<<8|uint16(data[i+1]))
+		} else {
+			units = append(units, uint16(data[i+1])<<8|uint16(data[i]))
+		}
+	}
+	return string(utf16.Decode(units))
+}
+
+// decodeWindows1252 decodes single-byte Windows-1252 text
+func decodeWindows1252(data []byte) string {
+	var text strings.Builder
+	text.Grow(len(data))
+	for _, b := range data {
+		if r, ok := cp1252[b]; ok {
+			text.WriteRune(r)
+			continue
+		}
+		text.WriteRune(rune(b))
+	}
+	return text.String()
+}
+
+// normalizeNewlines converts Windows and old Mac line endings to \n
+func normalizeNewlines(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return strings.ReplaceAll(text, "\r", "\n")
+}
+
+var (
+	mdHeadingRegex   = regexp.MustCompile(`^\s{0,3}#{1,6}\s+(.*?)\s*#*\s*$`)
+	mdSetextRegex    = regexp.MustCompile(`^\s{0,3}(=+|-+)\s*$`)
+	mdRuleRegex      = regexp.MustCompile(`^\s{0,3}([-*_]\s*){3,}$`)
+	mdListRegex      = regexp.MustCompile(`^(\s*)(?:[-*+]|\d+[.)])\s+`)
+	mdQuoteRegex     = regexp.MustCompile(`^\s*>\s?`)
+	mdImageRegex     = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	mdLinkRegex      = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)[^)]*\)`)
+	mdAutoLinkRegex  = regexp.MustCompile(`<((?:https?://|mailto:)[^>]+|[^\s<>@]+@[^\s<>@]+\.[^\s<>]+)>`)
+	mdStrongRegex    = regexp.MustCompile(`\*\*(.+?)\*\*|__(.+?)__`)
+	mdStarEmRegex    = regexp.MustCompile(`\*([^*\s][^*]*?)\*`)
+	mdUnderEmRegex   = regexp.MustCompile(`(^|[^A-Za-z0-9])_([^_\s][^_]*?)_([^A-Za-z0-9]|$)`)
+	mdCodeRegex      = regexp.MustCompile("`([^`]*)`")
+	mdHTMLTagRegex   = regexp.MustCompile(`</?[a-zA-Z][^>]*>`)
+	mdTableRuleRegex = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+	mdFenceRegex     = regexp.MustCompile("^\\s*(```|~~~)")
+	mdURLRegex       = regexp.MustCompile(`^(?:https?://|mailto:)`)
+)
+
+// markdownToBlocks strips Markdown syntax while keeping the document's line
+// structure. Headings become heading blocks so section detection sees them,
+// list items keep their depth and links keep their target.
+func markdownToBlocks(markdown string) []models.TextBlock {
+	var blocks []models.TextBlock
+	add := func(kind, text string, level int) {
+		if strings.TrimSpace(text) != "" {
+			blocks = append(blocks, models.TextBlock{Kind: kind, Text: strings.TrimRight(text, " \t"), Level: level})
+		}
+	}
+	inFence := false
+	// Only a line directly above can be a setext heading
+	previousText := false
+
+	for _, line := range strings.Split(markdown, "\n") {
+		if mdFenceRegex.MatchString(line) {
+			inFence = !inFence
+			previousText = false
+			continue
+		}
+		if inFence {
+			add(models.BlockParagraph, line, 0)
+			continue
+		}
+
+		// Setext headings underline the previous line
+		if match := mdSetextRegex.FindStringSubmatch(line); match != nil && previousText {
+			last := &blocks[len(blocks)-1]
+			last.Kind, last.Level = models.BlockHeading, 1
+			if strings.HasPrefix(match[1], "-") {
+				last.Level = 2
+			}
+			previousText = false
+			continue
+		}
+		previousText = false
+		if mdRuleRegex.MatchString(line) || mdTableRuleRegex.MatchString(line) && strings.Contains(line, "|") {
+			continue
+		}
+
+		if match := mdHeadingRegex.FindStringSubmatch(line); match != nil {
+			level := strings.Count(strings.SplitN(strings.TrimSpace(line), " ", 2)[0], "#")
+			add(models.BlockHeading, markdownInline(match[1]), level)
+			continue
+		}
+
+		line = mdQuoteRegex.ReplaceAllString(line, "")
+		if match := mdListRegex.FindStringSubmatch(line); match != nil {
+			level := len(strings.ReplaceAll(match[1], "\t", "  "))/2 + 1
+			add(models.BlockListItem, markdownInline(line[len(match[0]):]), level)
+			continue
+		}
+
+		// Table rows become cells separated by spaces
+		if strings.HasPrefix(strings.TrimSpace(line), "|") {
+			cells := strings.Split(strings.Trim(strings.TrimSpace(line), "|"), "|")
+			for i := range cells {
+				cells[i] = strings.TrimSpace(cells[i])
+			}
+			add(models.BlockTableRow, markdownInline(strings.Join(cells, "  ")), 0)
+			continue
+		}
+
+		if strings.TrimSpace(line) != "" {
+			add(models.BlockParagraph, markdownInline(line), 0)
+			previousText = true
+		}
+	}
+
+	return blocks
+}
+
+// markdownInline removes inline Markdown markup from a single line
+func markdownInline(line string) string {
+	line = mdImageRegex.ReplaceAllString(line, "$1")
+	line = mdLinkRegex.ReplaceAllStringFunc(line, func(link string) string {
+		match := mdLinkRegex.FindStringSubmatch(link)
+		label, target := match[1], match[2]
+		if label == target || mdURLRegex.MatchString(label) {
+			return target
+		}
+		return label + " (" + strings.TrimPrefix(target, "mailto:") + ")"
+	})
+	line = mdAutoLinkRegex.ReplaceAllString(line, "$1")
+	line = mdCodeRegex.ReplaceAllString(line, "$1")
+	line = mdStrongRegex.ReplaceAllString(line, "$1$2")
+	line = mdStarEmRegex.ReplaceAllString(line, "$1")
+	line = mdUnderEmRegex.ReplaceAllString(line, "$1$2$3")
+	line = mdHTMLTagRegex.ReplaceAllString(line, "")
+	line = strings.NewReplacer(`\*`, "*", `\_`, "_", `\#`, "#", `\-`, "-", `\.`, ".").Replace(line)
+	return line
+}
+
+// rtfSkipDestinations are RTF groups that hold metadata rather than text
+var rtfSkipDestinations = map[string]bool{
+	"fonttbl": true, "colortbl": true, "stylesheet": true, "info": true,
+	"pict": true, "object": true, "listtable": true, "listoverridetable": true,
+	"rsidtbl": true, "generator": true, "themedata": true, "colorschememapping": true,
+	"datastore": true, "latentstyles": true, "xmlnstbl": true, "mmathPr": true,
+	"fldinst": true, "bkmkstart": true, "bkmkend": true,
+}
+
+// rtfSymbols maps control words that stand for a character
+var rtfSymbols = map[string]string{
+	"par": "\n", "line": "\n", "sect": "\n", "page": "\n", "row": "\n",
+	"tab": "\t", "cell": "  ",
+	"bullet": "•", "emdash": "—", "endash": "–", "emspace": " ", "enspace": " ",
+	"lquote": "‘", "rquote": "’", "ldblquote": "“", "rdblquote": "”",
+}
+
+// rtfState is the formatting state of one RTF group
+type rtfState struct {
+	skip     bool
+	ucSkip   int
+	pendSkip int
+}
+
+// rtfToText strips RTF control words and groups, keeping paragraph breaks
+// and decoding hex (\'hh) and unicode (\uN) escapes
+func rtfToText(data []byte) string {
+	var text strings.Builder
+	stack := []rtfState{{ucSkip: 1}}
+	state := &stack[0]
+
+	// skipFallback drops the replacement characters that follow \uN
+	skipFallback := func() bool {
+		if state.pendSkip > 0 {
+			state.pendSkip--
+			return true
+		}
+		return false
+	}
+
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch c {
+		case '{':
+			stack = append(stack, *state)
+			state = &stack[len(stack)-1]
+			state.pendSkip = 0
+		case '}':
+			if len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+				state = &stack[len(stack)-1]
+			}
+		case '\r', '\n':
+			// Line breaks in RTF source are not part of the text
+		case '\\':
+			if i+1 >= len(data) {
+				break
+			}
+			next := data[i+1]
+			switch {
+			case next == '\\' || next == '{' || next == '}':
+				i++
+				if !skipFallback() && !state.skip {
+					text.WriteByte(next)
+				}
+			case next == '\'':
+				// \'hh is a byte in the document's code page
+				if i+3 < len(data) {
+					if b, err := strconv.ParseUint(string(data[i+2:i+4]), 16, 8); err == nil && !skipFallback() && !state.skip {
+						text.WriteString(decodeWindows1252([]byte{byte(b)}))
+					}
+				}
+				i += 3
+			case next == '*':
+				// \* marks an optional destination the reader may ignore
+				state.skip = true
+				i++
+			case next == '~':
+				i++
+				if !state.skip {
+					text.WriteRune(' ')
+				}
+			case next == '_':
+				i++
+				if !state.skip {
+					text.WriteRune('-')
+				}
+			case next == '-':
+				i++
+			case next == '\r' || next == '\n':
+				i++
+				if !state.skip {
+					text.WriteByte('\n')
+				}
+			case isASCIILetter(next):
+				j := i + 1
+				for j < len(data) && isASCIILetter(data[j]) {
+					j++
+				}
+				word := string(data[i+1 : j])
+
+				k := j
+				if k < len(data) && data[k] == '-' {
+					k++
+				}
+				for k < len(data) && data[k] >= '0' && data[k] <= '9' {
+					k++
+				}
+				param, hasParam := 0, k > j
+				if hasParam {
+					param, _ = strconv.Atoi(string(data[j:k]))
+				}
+				// A single space delimits the control word
+				if k < len(data) && data[k] == ' ' {
+					k++
+				}
+				i = k - 1
+
+				switch {
+				case rtfSkipDestinations[word]:
+					state.skip = true
+				case word == "uc" && hasParam:
+					state.ucSkip = param
+				case word == "u" && hasParam:
+					if param < 0 {
+						param += 65536
+					}
+					if !state.skip {
+						text.WriteRune(rune(param))
+					}
+					state.pendSkip = state.ucSkip
+				default:
+					if symbol, ok := rtfSymbols[word]; ok && !state.skip {
+						text.WriteString(symbol)
+					}
+				}
+			default:
+				i++
+			}
+		default:
+			if skipFallback() || state.skip {
+				continue
+			}
+			text.WriteByte(c)
+		}
+	}
+
+	// Plain ASCII bytes were written as-is, anything else came from escapes
+	result := text.String()
+	if !utf8.ValidString(result) {
+		result = strings.ToValidUTF8(result, "")
+	}
+	return result
+}
+
+// isASCIILetter reports whether b is an ASCII letter
+func isASCIILetter(b byte) bool {
+	return (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"ats-analyzer/models"
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+// extractBytes runs an extractor over a document held in memory
+func extractBytes(extractor Extractor, data []byte) ([]models.TextBlock, error) {
+	return extractor.Extract(bytes.NewReader(data), int64(len(data)))
+}
+
+// paragraphs builds the blocks of a document read one line per block
+func paragraphs(lines ...string) []models.TextBlock {
+	blocks := make([]models.TextBlock, len(lines))
+	for i, line := range lines {
+		blocks[i] = models.TextBlock{Kind: models.BlockParagraph, Text: line}
+	}
+	return blocks
+}
+
+func TestTextExtractors(t *testing.T) {
+	tests := []struct {
+		name      string
+		extractor Extractor
+		data      string
+		want      []models.TextBlock
+	}{
+		{
+			name:      "UTF-8 text",
+			extractor: txtExtractor{},
+			data:      "Jane Doe  \r\n\r\nSoftware Engineer\rCafé owner\n",
+			want:      paragraphs("Jane Doe", "Software Engineer", "Café owner"),
+		},
+		{
+			name:      "UTF-8 text with BOM",
+			extractor: txtExtractor{},
+			data:      "\xef\xbb\xbfJane Doe\n",
+			want:      paragraphs("Jane Doe"),
+		},
+		{
+			name:      "UTF-16 text",
+			extractor: txtExtractor{},
+			data:      "\xff\xfeJ\x00a\x00n\x00e\x00\n\x00G\x00o\x00",
+			want:      paragraphs("Jane", "Go"),
+		},
+		{
+			name:      "UTF-16 text without BOM",
+			extractor: txtExtractor{},
+			data:      "\x00J\x00a\x00n\x00e\x00 \x00D\x00o\x00e",
+			want:      paragraphs("Jane Doe"),
+		},
+		{
+			name:      "Windows-1252 text",
+			extractor: txtExtractor{},
+			data:      "Caf\xe9 \x93Go\x94 \x96 2021",
+			want:      paragraphs("Café “Go” – 2021"),
+		},
+		{
+			name:      "Markdown",
+			extractor: markdownExtractor{},
+			data: "# Jane Doe\n" +
+				"[LinkedIn](https://linkedin.com/in/jane) | <jane@example.com>\n\n" +
+				"Experience\n----------\n" +
+				"### Engineer at **Acme**\n" +
+				"- Built `Go` services\n" +
+				"  - Cut *latency* by 30%\n" +
+				"1. Shipped ![logo](x.png) billing\n\n" +
+				"| Skill | Years |\n|-------|------:|\n| Go | 5 |\n\n" +
+				"```\nmake test\n```\n---\n",
+			want: []models.TextBlock{
+				{Kind: models.BlockHeading, Text: "Jane Doe", Level: 1},
+				{Kind: models.BlockParagraph, Text: "LinkedIn (https://linkedin.com/in/jane) | jane@example.com"},
+				{Kind: models.BlockHeading, Text: "Experience", Level: 2},
+				{Kind: models.BlockHeading, Text: "Engineer at Acme", Level: 3},
+				{Kind: models.BlockListItem, Text: "Built Go services", Level: 1},
+				{Kind: models.BlockListItem, Text: "Cut latency by 30%", Level: 2},
+				{Kind: models.BlockListItem, Text: "Shipped logo billing", Level: 1},
+				{Kind: models.BlockTableRow, Text: "Skill  Years"},
+				{Kind: models.BlockTableRow, Text: "Go  5"},
+				{Kind: models.BlockParagraph, Text: "make test"},
+			},
+		},
+		{
+			name:      "RTF",
+			extractor: rtfExtractor{},
+			data: `{\rtf1\ansi\deff0{\fonttbl{\f0 Arial;}}{\colortbl;\red0\green0\blue0;}` +
+				`{\info{\author Someone Else}}` + "\r\n" +
+				`\f0\fs28 Jane Doe\par` + "\r\n" +
+				`Caf\'e9 \b owner\b0\par ` +
+				`Go\tab Rust\par` +
+				`{\*\generator Writer;}2019\endash 2021 \u8212? shipped\line {\field{\*\fldinst HYPERLINK "x"}{\fldrslt site}}}`,
+			want: paragraphs("Jane Doe", "Café owner", "Go\tRust", "2019–2021 — shipped", "site"),
+		},
+		{
+			name:      "truncated RTF",
+			extractor: rtfExtractor{},
+			data:      `{\rtf1\ansi Jane Doe\par Go develo`,
+			want:      paragraphs("Jane Doe", "Go develo"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blocks, err := extractBytes(tt.extractor, []byte(tt.data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(blocks, tt.want) {
+				t.Errorf("got  %+v\nwant %+v", blocks, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,259 @@
+package services
+
+import (
+	"ats-analyzer/models"
+	"regexp"
+	"strings"
+)
+
+// seniorityLevels maps seniority words to a rank used to compare levels
+var seniorityLevels = map[string]int{
+	"intern":       0,
+	"trainee":      0,
+	"junior":       1,
+	"jr":           1,
+	"entry":        1,
+	"associate":    1,
+	"i":            1,
+	"mid":          2,
+	"ii":           2,
+	"intermediate": 2,
+	"senior":       3,
+	"sr":           3,
+	"iii":          3,
+	"lead":         4,
+	"staff":        4,
+	"principal":    5,
+	"head":         5,
+	"chief":        6,
+}
+
+// titleNoiseWords carry no meaning for title comparison
+var titleNoiseWords = map[string]bool{
+	"level": true, "of": true, "the": true, "and": true, "remote": true,
+	"hybrid": true, "onsite": true, "contract": true, "fulltime": true,
+	"full": true, "time": true, "part": true,
+}
+
+// titleFamilies groups job titles that describe the same role. Titles are
+// compared after seniority and noise words have been removed.
+var titleFamilies = map[string][]string{
+	"software engineer": {
+		"software engineer", "software developer", "software development engineer",
+		"programmer", "application developer", "developer", "engineer", "sde",
+	},
+	"backend engineer": {
+		"backend engineer", "backend developer", "back end engineer", "back end developer",
+		"server side engineer", "api developer",
+	},
+	"frontend engineer": {
+		"frontend engineer", "frontend developer", "front end engineer", "front end developer",
+		"ui engineer", "ui developer", "web developer",
+	},
+	"full stack engineer": {
+		"full stack engineer", "full stack developer", "fullstack engineer", "fullstack developer",
+	},
+	"devops engineer": {
+		"devops engineer", "site reliability engineer", "sre", "platform engineer",
+		"infrastructure engineer", "cloud engineer", "build engineer",
+	},
+	"data analyst": {
+		"data analyst", "bi analyst", "business intelligence analyst", "reporting analyst",
+		"analytics analyst", "business analyst", "insights analyst",
+	},
+	"data scientist": {
+		"data scientist", "machine learning scientist", "applied scientist", "research scientist",
+	},
+	"machine learning engineer": {
+		"machine learning engineer", "ml engineer", "ai engineer", "deep learning engineer",
+	},
+	"data engineer": {
+		"data engineer", "etl developer", "big data engineer", "analytics engineer",
+	},
+	"qa engineer": {
+		"qa engineer", "quality assurance engineer", "test engineer", "sdet",
+		"software engineer in test", "qa analyst", "tester",
+	},
+	"product manager": {
+		"product manager", "product owner", "technical product manager",
+	},
+	"project manager": {
+		"project manager", "program manager", "delivery manager", "scrum master",
+	},
+	"engineering manager": {
+		"engineering manager", "software engineering manager", "development manager",
+		"manager software engineering",
+	},
+	"designer": {
+		"designer", "ux designer", "ui designer", "product designer", "ui ux designer",
+		"interaction designer",
+	},
+	"mobile engineer": {
+		"mobile engineer", "mobile developer", "ios developer", "ios engineer",
+		"android developer", "android engineer",
+	},
+}
+
+// titleRoleWords name a role, so a title outside the known families, such
+// as "Staff Accountant", is still recognised as a job title
+var titleRoleWords = map[string]bool{
+	"engineer": true, "developer": true, "programmer": true, "architect": true,
+	"analyst": true, "scientist": true, "researcher": true, "manager": true,
+	"director": true, "designer": true, "consultant": true, "specialist": true,
+	"administrator": true, "coordinator": true, "officer": true, "accountant": true,
+	"technician": true, "assistant": true, "representative": true, "executive": true,
+	"recruiter": true, "writer": true, "editor": true, "strategist": true,
+	"nurse": true, "teacher": true, "sre": true, "sdet": true, "tester": true,
+}
+
+var (
+	titlePrefixRegex = regexp.MustCompile(`(?i)^(job\s+title|position|role|title)\s*[:\-]\s*`)
+	// Drop location or company suffixes such as "- Remote", "(m/f/d)", "at Acme"
+	titleSuffixRegex = regexp.MustCompile(`(?i)(\s+[-–—|@]\s+.*|\s*\(.*\)|\s+at\s+.*)$`)
+	titleTokenRegex  = regexp.MustCompile(`[a-z0-9+#]+`)
+)
+
+// titleFamilyIndex maps a normalized title to its family
+var titleFamilyIndex = func() map[string]string {
+	index := make(map[string]string)
+	for family, titles := range titleFamilies {
+		for _, title := range titles {
+			index[title] = family
+		}
+	}
+	return index
+}()
+
+// normalizeTitle strips prefixes, suffixes, seniority and noise words from
+// a job title and returns the remaining title and its seniority rank, or -1
+// when no seniority is stated
+func normalizeTitle(title string) (string, int) {
+	title = strings.TrimSpace(title)
+	title = titlePrefixRegex.ReplaceAllString(title, "")
+	title = titleSuffixRegex.ReplaceAllString(title, "")
+	title = strings.ToLower(title)
+	title = strings.NewReplacer("front-end", "front end", "back-end", "back end",
+		"full-stack", "full stack", "sr.", "sr", "jr.", "jr").Replace(title)
+
+	seniority := -1
+	var words []string
+	for _, token := range titleTokenRegex.FindAllString(title, -1) {
+		if level, ok := seniorityLevels[token]; ok {
+			if level > seniority {
+				seniority = level
+			}
+			continue
+		}
+		if titleNoiseWords[token] {
+			continue
+		}
+		words = append(words, token)
+	}
+
+	return strings.Join(words, " "), seniority
+}
+
+// titleFamily returns the family a normalized title belongs to
+func titleFamily(normalized string) string {
+	if family, ok := titleFamilyIndex[normalized]; ok {
+		return family
+	}
+	return ""
+}
+
+// isRecognizedTitle reports whether a normalized job title names a role,
+// either from a known family or by containing a role word. Job descriptions
+// often open with a company blurb, which then ends up as the title.
+func isRecognizedTitle(normalized string) bool {
+	if titleFamily(normalized) != "" {
+		return true
+	}
+	for _, word := range strings.Fields(normalized) {
+		if titleRoleWords[word] {
+			return true
+		}
+	}
+	return false
+}
+
+// titleSimilarity scores two raw titles between 0 and 1
+func titleSimilarity(jobTitle, candidateTitle string) (float64, bool) {
+	jobNorm, jobLevel := normalizeTitle(jobTitle)
+	candNorm, candLevel := normalizeTitle(candidateTitle)
+	if jobNorm == "" || candNorm == "" {
+		return 0, false
+	}
+
+	var score float64
+	sameFamily := false
+	switch {
+	case jobNorm == candNorm:
+		score = 1.0
+	case titleFamily(jobNorm) != "" && titleFamily(jobNorm) == titleFamily(candNorm):
+		score = 0.9
+		sameFamily = true
+	default:
+		// Partial credit for shared words, e.g. Data Analyst vs Data Scientist
+		score = tokenOverlap(jobNorm, candNorm) * 0.7
+	}
+
+	// Small penalty when the candidate is more than one level below the role
+	if jobLevel >= 0 && candLevel >= 0 && jobLevel-candLevel > 1 {
+		score *= 0.85
+	}
+
+	return score, sameFamily || jobNorm == candNorm
+}
+
+// tokenOverlap returns the Jaccard similarity of the words in two titles
+func tokenOverlap(a, b string) float64 {
+	setA := make(map[string]bool)
+	for _, w := range strings.Fields(a) {
+		setA[w] = true
+	}
+	setB := make(map[string]bool)
+	for _, w := range strings.Fields(b) {
+		setB[w] = true
+	}
+
+	shared := 0
+	for w := range setB {
+		if setA[w] {
+			shared++
+		}
+	}
+
+	union := len(setA) + len(setB) - shared
+	if union == 0 {
+		return 0
+	}
+	return float64(shared) / float64(union)
+}
+
+// calculateTitleMatch compares the job title to every position the
+// candidate has held and keeps the best match
+func (s *Scorer) calculateTitleMatch(resume *models.Resume, jobDesc *models.JobDescription) models.TitleMatchResult {
+	jobNorm, jobLevel := normalizeTitle(jobDesc.Title)
+	result := models.TitleMatchResult{
+		JobTitle:           jobDesc.Title,
+		NormalizedJobTitle: jobNorm,
+		JobFamily:          titleFamily(jobNorm),
+		SeniorityMatch:     true,
+	}
+
+	for _, exp := range resume.Experience {
+		// Position and company are not always split correctly, try both
+		for _, candidate := range []string{exp.Position, exp.Company} {
+			score, sameFamily := titleSimilarity(jobDesc.Title, candidate)
+			if score > result.Score {
+				_, candLevel := normalizeTitle(candidate)
+				result.Score = score
+				result.BestMatch = candidate
+				result.SameFamily = sameFamily
+				result.SeniorityMatch = jobLevel < 0 || candLevel < 0 || candLevel >= jobLevel-1
+			}
+		}
+	}
+
+	return result
+}
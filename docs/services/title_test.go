@@ -0,0 +1,42 @@
+package services
+
+import (
+	"ats-analyzer/models"
+	"math"
+	"testing"
+)
+
+func TestAnalyzeResumeReweightsWithoutJobTitle(t *testing.T) {
+	tests := []struct {
+		title      string
+		titleScore bool
+	}{
+		{"Senior Backend Engineer", true},
+		{"Staff Accountant - Remote", true},
+		{"", false},
+		{"About Acme Corp", false},
+		{"We are a fast growing fintech startup", false},
+	}
+
+	scorer := NewScorer()
+	weights := scorer.profile.Weights
+	total := weights.SkillWeight + weights.TitleWeight + weights.ExperienceWeight + weights.EducationWeight + weights.FormatWeight
+	resume := &models.Resume{
+		Experience: []models.Experience{{Position: "Backend Engineer", Company: "Globex"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			result := scorer.AnalyzeResume(resume, &models.JobDescription{Title: tt.title})
+			breakdown := result.ScoreBreakdown
+
+			if got := breakdown.TitleWeight > 0; got != tt.titleScore {
+				t.Errorf("title weight = %.2f, want title scored: %v", breakdown.TitleWeight, tt.titleScore)
+			}
+			sum := breakdown.SkillWeight + breakdown.TitleWeight + breakdown.ExperienceWeight + breakdown.EducationWeight + breakdown.FormatWeight
+			if math.Abs(sum-total) > 1e-9 {
+				t.Errorf("weights sum to %.4f, want %.4f", sum, total)
+			}
+		})
+	}
+}
@@ -0,0 +1,289 @@
+package services
+
+import (
+	"archive/zip"
+	"ats-analyzer/utils"
+	"compress/flate"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// Codes identifying why an upload was rejected, returned to clients as the
+// error code
+const (
+	CodeUnsupportedFormat = "unsupported_format"
+	CodeFormatMismatch    = "format_mismatch"
+	CodeEmptyFile         = "empty_file"
+	CodeFileTooLarge      = "file_too_large"
+	CodeTooManyPages      = "too_many_pages"
+	CodeArchiveTooLarge   = "archive_too_large"
+	CodeCorruptFile       = "corrupt_file"
+)
+
+// UploadError is an upload rejected before parsing, with a code clients can
+// act on
+type UploadError struct {
+	Code    string
+	Message string
+}
+
+func (e *UploadError) Error() string {
+	return e.Message
+}
+
+// uploadError builds an UploadError from a formatted message
+func uploadError(code, format string, args ...interface{}) *UploadError {
+	return &UploadError{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// ErrorCode returns the rejection code of an upload error or the reason a
+// resume is unreadable, or an empty string for other errors
+func ErrorCode(err error) string {
+	var uploadErr *UploadError
+	if errors.As(err, &uploadErr) {
+		return uploadErr.Code
+	}
+	var unreadableErr *UnreadableError
+	if errors.As(err, &unreadableErr) {
+		return unreadableErr.Reason
+	}
+	return ""
+}
+
+// UploadLimits bounds the documents the analyzer will open
+type UploadLimits struct {
+	// MaxPages is the longest resume accepted
+	MaxPages int
+	// MaxUncompressedSize bounds the total size of an archive's entries
+	MaxUncompressedSize int64
+	// MaxCompressionRatio bounds how far any archive entry may expand
+	MaxCompressionRatio int64
+	// MaxArchiveEntries bounds the number of files in an archive
+	MaxArchiveEntries int
+}
+
+// DefaultUploadLimits are generous for a resume while keeping decompression
+// bombs and book-length documents out
+var DefaultUploadLimits = UploadLimits{
+	MaxPages:            30,
+	MaxUncompressedSize: 50 * 1024 * 1024,
+	MaxCompressionRatio: 100,
+	MaxArchiveEntries:   1000,
+}
+
+// ContentValidator is implemented by extractors that can check a file
+// really is in their format, and within the limits, before extracting it
+type ContentValidator interface {
+	Validate(r io.ReaderAt, size int64, limits UploadLimits) error
+}
+
+// CheckFile rejects an upload by its name and size before it is saved
+func (r *ExtractorRegistry) CheckFile(name string, size int64) error {
+	if err := utils.ValidateFileSize(size); err != nil {
+		if size == 0 {
+			return &UploadError{Code: CodeEmptyFile, Message: err.Error()}
+		}
+		return &UploadError{Code: CodeFileTooLarge, Message: err.Error()}
+	}
+	if r.byName(name) == nil {
+		return uploadError(CodeUnsupportedFormat, "invalid file format, only %s files are supported", r.FormatNames())
+	}
+	return nil
+}
+
+// CheckContent verifies an upload is the format its name claims and within
+// the upload limits. The content is sniffed so a renamed file is rejected
+// rather than parsed as the wrong format.
+func (r *ExtractorRegistry) CheckContent(name string, reader io.ReaderAt, size int64) error {
+	named := r.byName(name)
+	if named == nil {
+		return uploadError(CodeUnsupportedFormat, "invalid file format, only %s files are supported", r.FormatNames())
+	}
+
+	head, err := readHead(reader, size)
+	if err != nil {
+		return err
+	}
+	if len(head) == 0 {
+		return uploadError(CodeEmptyFile, "file is empty")
+	}
+
+	// Formats are compared by name, as extractors need not be comparable
+	expected := named.Format().Name
+	if sniffed := r.byContent(head); sniffed != nil && sniffed.Format().Name != expected {
+		return uploadError(CodeFormatMismatch, "file content is %s but its %s extension says %s",
+			sniffed.Format().Name, filepath.Ext(name), expected)
+	}
+
+	if validator, ok := named.(ContentValidator); ok {
+		return validator.Validate(reader, size, r.Limits())
+	}
+	return nil
+}
+
+// Limits returns the limits content checks apply
+func (r *ExtractorRegistry) Limits() UploadLimits {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.limits
+}
+
+// SetLimits changes the limits content checks apply
+func (r *ExtractorRegistry) SetLimits(limits UploadLimits) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.limits = limits
+}
+
+// checkArchive opens a ZIP-based document and guards against decompression
+// bombs by inflating every entry under the size limits
+func checkArchive(r io.ReaderAt, size int64, format string, limits UploadLimits) (*zip.Reader, error) {
+	reader, err := zip.NewReader(r, size)
+	if errors.Is(err, zip.ErrFormat) {
+		return nil, uploadError(CodeFormatMismatch, "file is not a %s document", format)
+	}
+	if err != nil {
+		return nil, uploadError(CodeCorruptFile, "failed to open %s document: %v", format, err)
+	}
+
+	if len(reader.File) > limits.MaxArchiveEntries {
+		return nil, uploadError(CodeArchiveTooLarge, "document holds too many files: %d (max: %d)", len(reader.File), limits.MaxArchiveEntries)
+	}
+
+	// Declared sizes can lie, so entries are inflated and counted
+	var total int64
+	for _, file := range reader.File {
+		if err := inflateEntry(file, limits, &total); err != nil {
+			return nil, err
+		}
+	}
+
+	return reader, nil
+}
+
+// inflateEntry decompresses one archive entry, adding its size to total and
+// failing once the limits are exceeded
+func inflateEntry(file *zip.File, limits UploadLimits, total *int64) error {
+	content, err := openRawEntry(file)
+	if err != nil {
+		return uploadError(CodeCorruptFile, "failed to read %s: %v", file.Name, err)
+	}
+	defer content.Close()
+
+	remaining := limits.MaxUncompressedSize - *total
+	size, err := io.Copy(io.Discard, io.LimitReader(content, remaining+1))
+	if err != nil {
+		return uploadError(CodeCorruptFile, "failed to read %s: %v", file.Name, err)
+	}
+	*total += size
+
+	if *total > limits.MaxUncompressedSize {
+		return uploadError(CodeArchiveTooLarge, "document expands beyond %d bytes", limits.MaxUncompressedSize)
+	}
+	// Small entries compress well without being dangerous
+	if size > 1024*1024 && file.CompressedSize64 > 0 && size/int64(file.CompressedSize64) > limits.MaxCompressionRatio {
+		return uploadError(CodeArchiveTooLarge, "%s expands more than %dx", file.Name, limits.MaxCompressionRatio)
+	}
+	return nil
+}
+
+// openRawEntry opens an archive entry for inflating without the zip
+// reader's checks against its declared size, which would stop a lying entry
+// before the limits can measure how far it really expands
+func openRawEntry(file *zip.File) (io.ReadCloser, error) {
+	if file.Method != zip.Store && file.Method != zip.Deflate {
+		return file.Open()
+	}
+
+	raw, err := file.OpenRaw()
+	if err != nil {
+		return nil, err
+	}
+	if file.Method == zip.Store {
+		return io.NopCloser(raw), nil
+	}
+	return flate.NewReader(raw), nil
+}
+
+// Limits on the archive entries read whole into memory
+const (
+	// maxArchiveMetadataSize bounds small parts such as content types,
+	// relationships and document properties
+	maxArchiveMetadataSize = 1024 * 1024
+	// maxArchivePartSize bounds document bodies and style sheets
+	maxArchivePartSize = 16 * 1024 * 1024
+)
+
+// readArchiveEntry returns the content of a named archive entry, or nil
+// when the archive has no such entry. An entry larger than maxSize is an
+// error rather than being cut short.
+func readArchiveEntry(reader *zip.Reader, name string, maxSize int64) ([]byte, error) {
+	for _, file := range reader.File {
+		if file.Name != name {
+			continue
+		}
+		content, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer content.Close()
+
+		data, err := io.ReadAll(io.LimitReader(content, maxSize+1))
+		if err != nil {
+			return nil, err
+		}
+		if int64(len(data)) > maxSize {
+			return nil, uploadError(CodeArchiveTooLarge, "%s is larger than %d bytes", name, maxSize)
+		}
+		return data, nil
+	}
+	return nil, nil
+}
+
+// archiveReadError reports a failure to read an archive entry as a corrupt
+// file, keeping the code of an entry rejected for its size
+func archiveReadError(what string, err error) error {
+	var uploadErr *UploadError
+	if errors.As(err, &uploadErr) {
+		return err
+	}
+	return uploadError(CodeCorruptFile, "failed to read %s: %v", what, err)
+}
+
+// checkPageCount rejects documents longer than the page limit
+func checkPageCount(pages int, limits UploadLimits) error {
+	if limits.MaxPages > 0 && pages > limits.MaxPages {
+		return uploadError(CodeTooManyPages, "document has too many pages: %d (max: %d)", pages, limits.MaxPages)
+	}
+	return nil
+}
+
+// checkText rejects documents of a text format whose content is binary
+func checkText(r io.ReaderAt, size int64, format string) error {
+	head, err := readHead(r, size)
+	if err != nil {
+		return err
+	}
+	if !looksLikeText(head) {
+		return uploadError(CodeFormatMismatch, "file is not a %s document", format)
+	}
+	return nil
+}
+
+// looksLikeText reports whether data decodes to text without a noticeable
+// share of control characters, as binary files do
+func looksLikeText(data []byte) bool {
+	text := decodeText(data)
+	total, control := 0, 0
+	for _, r := range text {
+		total++
+		if r == 0 || unicode.IsControl(r) && !strings.ContainsRune("\n\t\f", r) {
+			control++
+		}
+	}
+	return total > 0 && control*20 < total
+}
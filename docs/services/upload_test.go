@@ -0,0 +1,198 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/flate"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"strings"
+	"testing"
+)
+
+func TestReadArchiveEntry(t *testing.T) {
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for name, size := range map[string]int{"small.xml": 10, "exact.xml": 64, "big.xml": 65} {
+		w, err := writer.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(strings.Repeat("x", size)))
+	}
+	writer.Close()
+	reader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, size := range map[string]int{"small.xml": 10, "exact.xml": 64} {
+		data, err := readArchiveEntry(reader, name, 64)
+		if err != nil || len(data) != size {
+			t.Errorf("%s: got %d bytes, err %v, want %d bytes", name, len(data), err, size)
+		}
+	}
+
+	data, err := readArchiveEntry(reader, "missing.xml", 64)
+	if data != nil || err != nil {
+		t.Errorf("missing entry: got %q, err %v, want neither", data, err)
+	}
+
+	// An oversized entry is rejected rather than cut short
+	_, err = readArchiveEntry(reader, "big.xml", 64)
+	var uploadErr *UploadError
+	if !errors.As(archiveReadError("big entry", err), &uploadErr) || uploadErr.Code != CodeArchiveTooLarge {
+		t.Errorf("big entry: err = %v, want code %s", err, CodeArchiveTooLarge)
+	}
+
+	if err := archiveReadError("entry", errors.New("zip: checksum error")); !errors.As(err, &uploadErr) || uploadErr.Code != CodeCorruptFile {
+		t.Errorf("read failure: err = %v, want code %s", err, CodeCorruptFile)
+	}
+}
+
+// buildPDF writes a minimal PDF with the given number of blank pages
+func buildPDF(pages int) []byte {
+	var buf bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n")
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	kids := make([]string, pages)
+	for i := range kids {
+		kids[i] = fmt.Sprintf("%d 0 R", i+3)
+	}
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), pages))
+	for i := 0; i < pages; i++ {
+		object("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] >>")
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return buf.Bytes()
+}
+
+// docxArchive builds a DOCX holding the given extra entries
+func docxArchive(t *testing.T, entries ...string) []byte {
+	return buildZip(t, append([]string{
+		"[Content_Types].xml", `<Types><Override ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/></Types>`,
+		"word/document.xml", docxPartXML("document", "<w:body>"+docxParagraph("Jane Doe")+"</w:body>"),
+	}, entries...)...)
+}
+
+// bombArchive builds a DOCX with an entry inflating to size zero bytes
+// whose header claims it holds declared bytes
+func bombArchive(t *testing.T, size int, declared uint64) []byte {
+	t.Helper()
+	content := make([]byte, size)
+	var compressed bytes.Buffer
+	w, _ := flate.NewWriter(&compressed, flate.BestCompression)
+	w.Write(content)
+	w.Close()
+
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for _, entry := range [][2]string{
+		{"[Content_Types].xml", `<Types><Override ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/></Types>`},
+		{"word/document.xml", docxPartXML("document", "<w:body/>")},
+	} {
+		w, _ := writer.Create(entry[0])
+		w.Write([]byte(entry[1]))
+	}
+	raw, err := writer.CreateRaw(&zip.FileHeader{
+		Name:               "word/media/image1.png",
+		Method:             zip.Deflate,
+		CRC32:              crc32.ChecksumIEEE(content),
+		CompressedSize64:   uint64(compressed.Len()),
+		UncompressedSize64: declared,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw.Write(compressed.Bytes())
+	writer.Close()
+	return buf.Bytes()
+}
+
+func TestCheckContent(t *testing.T) {
+	smallArchives := DefaultUploadLimits
+	smallArchives.MaxUncompressedSize = 1024 * 1024
+	fewPages := DefaultUploadLimits
+	fewPages.MaxPages = 2
+	fewEntries := DefaultUploadLimits
+	fewEntries.MaxArchiveEntries = 3
+
+	odt := buildZip(t, "mimetype", "application/vnd.oasis.opendocument.text",
+		"content.xml", odtContent("<text:p>Jane Doe</text:p>"),
+		"meta.xml", `<office:document-meta><meta:document-statistic meta:page-count="3"/></office:document-meta>`)
+
+	tests := []struct {
+		name   string
+		file   string
+		data   []byte
+		limits UploadLimits
+		code   string
+	}{
+		{name: "PDF", file: "resume.pdf", data: buildPDF(2)},
+		{name: "DOCX", file: "resume.docx", data: docxArchive(t)},
+		{name: "text", file: "resume.txt", data: []byte("Jane Doe\nGo developer")},
+		{name: "unknown extension", file: "resume.exe", data: []byte("MZ"), code: CodeUnsupportedFormat},
+		{name: "empty", file: "resume.pdf", data: nil, code: CodeEmptyFile},
+		{name: "DOCX named PDF", file: "resume.pdf", data: docxArchive(t), code: CodeFormatMismatch},
+		{name: "PDF named DOCX", file: "resume.docx", data: buildPDF(1), code: CodeFormatMismatch},
+		{name: "ODT named DOCX", file: "resume.docx", data: odt, code: CodeFormatMismatch},
+		{name: "PDF without a header", file: "resume.pdf", data: []byte("Jane Doe"), code: CodeFormatMismatch},
+		{name: "binary text", file: "resume.txt", data: []byte("\x00\x01\x02\x03MZ\x90\x00\x03"), code: CodeFormatMismatch},
+		{name: "RTF without a header", file: "resume.rtf", data: []byte("Jane Doe"), code: CodeFormatMismatch},
+		{name: "archive of another kind", file: "resume.docx", data: buildZip(t, "xl/workbook.xml", "<workbook/>"), code: CodeFormatMismatch},
+		{name: "corrupt PDF", file: "resume.pdf", data: []byte("%PDF-1.4\ngarbage"), code: CodeCorruptFile},
+		{name: "PDF over the page limit", file: "resume.pdf", data: buildPDF(3), limits: fewPages, code: CodeTooManyPages},
+		{name: "ODT over the page limit", file: "resume.odt", data: odt, limits: fewPages, code: CodeTooManyPages},
+		{name: "DOCX over the page limit", file: "resume.docx", data: docxArchive(t, "docProps/app.xml", "<Properties><Pages>31</Pages></Properties>"), code: CodeTooManyPages},
+		{name: "entry larger than declared", file: "resume.docx", data: bombArchive(t, 2*1024*1024, 1024), limits: smallArchives, code: CodeArchiveTooLarge},
+		{name: "entry expanding too far", file: "resume.docx", data: bombArchive(t, 2*1024*1024, 2*1024*1024), code: CodeArchiveTooLarge},
+		{name: "too many entries", file: "resume.docx", data: docxArchive(t, "a.xml", "", "b.xml", ""), limits: fewEntries, code: CodeArchiveTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewExtractorRegistry(builtinExtractors()...)
+			if tt.limits != (UploadLimits{}) {
+				registry.SetLimits(tt.limits)
+			}
+			err := registry.CheckContent(tt.file, bytes.NewReader(tt.data), int64(len(tt.data)))
+			if code := ErrorCode(err); code != tt.code || err != nil && tt.code == "" {
+				t.Errorf("err = %v (code %q), want code %q", err, code, tt.code)
+			}
+		})
+	}
+}
+
+func TestCheckFile(t *testing.T) {
+	tests := []struct {
+		name string
+		size int64
+		code string
+	}{
+		{"resume.pdf", 1024, ""},
+		{"Resume.DOCX", 1024, ""},
+		{"resume.pdf", 0, CodeEmptyFile},
+		{"resume.pdf", 100 * 1024 * 1024, CodeFileTooLarge},
+		{"resume.doc", 1024, CodeUnsupportedFormat},
+	}
+
+	for _, tt := range tests {
+		err := DefaultExtractors().CheckFile(tt.name, tt.size)
+		if code := ErrorCode(err); code != tt.code || err != nil && tt.code == "" {
+			t.Errorf("CheckFile(%q, %d) = %v, want code %q", tt.name, tt.size, err, tt.code)
+		}
+	}
+}
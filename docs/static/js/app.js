@@ -4,13 +4,51 @@ class ATSAnalyzer {
         this.loadingState = document.getElementById('loadingState');
         this.errorAlert = document.getElementById('errorAlert');
         this.resultsSection = document.getElementById('resultsSection');
+        this.unreadableAlert = document.getElementById('unreadableAlert');
         this.analyzeBtn = document.getElementById('analyzeBtn');
         
         this.scoreChart = null;
         this.breakdownChart = null;
+
+        // Replaced by the server's list once loadFormats returns
+        this.formats = [
+            { name: 'PDF', extensions: ['.pdf'] },
+            { name: 'DOCX', extensions: ['.docx'] },
+            { name: 'ODT', extensions: ['.odt'] },
+            { name: 'HTML', extensions: ['.html', '.htm'] },
+            { name: 'TXT', extensions: ['.txt'] },
+            { name: 'Markdown', extensions: ['.md', '.markdown'] },
+            { name: 'RTF', extensions: ['.rtf'] }
+        ];
         
         this.initializeEventListeners();
         this.setupFileUpload();
+        this.loadFormats();
+    }
+
+    async loadFormats() {
+        try {
+            const response = await fetch('/api/v1/formats');
+            if (!response.ok) return;
+
+            const result = await response.json();
+            if (!result.data || !result.data.formats || result.data.formats.length === 0) return;
+            this.formats = result.data.formats;
+        } catch (error) {
+            console.error('Failed to load supported formats:', error);
+            return;
+        }
+
+        const extensions = this.formats.flatMap(format => format.extensions);
+        document.getElementById('resumeFile').setAttribute('accept', extensions.join(','));
+        document.getElementById('supportedFormats').textContent =
+            `Supported formats: ${this.formats.map(format => format.name).join(', ')} (Max 10MB)`;
+    }
+
+    formatNames() {
+        const names = this.formats.map(format => format.name);
+        if (names.length < 2) return names.join('');
+        return `${names.slice(0, -1).join(', ')} or ${names[names.length - 1]}`;
     }
 
     initializeEventListeners() {
@@ -56,11 +94,13 @@ class ATSAnalyzer {
     validateFile(file) {
         if (!file) return;
 
-        const validTypes = ['application/pdf', 'application/vnd.openxmlformats-officedocument.wordprocessingml.document'];
+        // Text formats have no reliable MIME type, so check the extension
+        const validExtensions = this.formats.flatMap(format => format.extensions);
         const maxSize = 10 * 1024 * 1024; // 10MB
+        const extension = file.name.slice(file.name.lastIndexOf('.')).toLowerCase();
 
-        if (!validTypes.includes(file.type)) {
-            this.showError('Please select a PDF or DOCX file.');
+        if (!validExtensions.includes(extension)) {
+            this.showError(`Please select a ${this.formatNames()} file.`);
             return false;
         }
 
@@ -104,21 +144,22 @@ class ATSAnalyzer {
             this.hideError();
             this.hideResults();
 
-            const response = await fetch('/api/v1/analyze', {
+            // Stream stage events so the loading state shows real progress
+            const response = await fetch('/api/v1/analyze/stream', {
                 method: 'POST',
                 body: formData
             });
 
-            const result = await response.json();
-
             if (!response.ok) {
+                const result = await response.json();
                 throw new Error(result.error || 'Analysis failed');
             }
 
-            if (result.success && result.data) {
-                this.displayResults(result.data);
+            const analysis = await this.readAnalysisStream(response);
+            if (analysis.status === 'not_ats_readable') {
+                this.displayUnreadable(analysis);
             } else {
-                throw new Error('Invalid response format');
+                this.displayResults(analysis);
             }
 
         } catch (error) {
@@ -129,6 +170,68 @@ class ATSAnalyzer {
         }
     }
 
+    async readAnalysisStream(response) {
+        const reader = response.body.getReader();
+        const decoder = new TextDecoder();
+        let buffer = '';
+
+        while (true) {
+            const { value, done } = await reader.read();
+            if (done) break;
+            buffer += decoder.decode(value, { stream: true });
+
+            // Server-Sent Events are separated by a blank line
+            let boundary;
+            while ((boundary = buffer.indexOf('\n\n')) !== -1) {
+                const event = this.parseStreamEvent(buffer.slice(0, boundary));
+                buffer = buffer.slice(boundary + 2);
+                if (!event) continue;
+
+                if (event.stage === 'complete') {
+                    return event.data;
+                }
+                if (event.stage === 'error') {
+                    throw new Error((event.data && event.data.error) || 'Analysis failed');
+                }
+                this.updateProgress(event);
+            }
+        }
+
+        throw new Error('Analysis ended unexpectedly. Please try again.');
+    }
+
+    parseStreamEvent(chunk) {
+        const data = chunk.split('\n')
+            .filter(line => line.startsWith('data:'))
+            .map(line => line.slice(5))
+            .join('\n');
+
+        return data ? JSON.parse(data) : null;
+    }
+
+    updateProgress(event) {
+        const messages = {
+            uploaded: 'Resume uploaded, extracting text...',
+            text_extracted: 'Text extracted, detecting sections...',
+            sections_detected: 'Sections detected, reading your experience and skills...',
+            resume_parsed: 'Resume parsed, reading the job description...',
+            jd_parsed: 'Job description parsed, scoring...',
+            scored: 'Scoring complete!'
+        };
+
+        let message = messages[event.stage] || 'Analyzing your resume...';
+
+        // Show partial results as soon as they are available
+        if (event.stage === 'text_extracted' && event.data) {
+            message = `Extracted ${event.data.words} words, detecting sections...`;
+        } else if (event.stage === 'resume_parsed' && event.data && event.data.skills) {
+            message = `Found ${event.data.skills.length} skills, reading the job description...`;
+        }
+
+        document.getElementById('loadingMessage').textContent = message;
+        document.getElementById('analysisProgress').style.width = `${event.progress}%`;
+    }
+
     displayResults(analysis) {
         this.hideLoading();
         this.hideError();
@@ -156,6 +259,16 @@ class ATSAnalyzer {
         this.resultsSection.scrollIntoView({ behavior: 'smooth' });
     }
 
+    displayUnreadable(result) {
+        this.hideLoading();
+        this.hideError();
+
+        // An unreadable resume is explained rather than given a score
+        document.getElementById('unreadableMessage').textContent = result.message;
+        this.unreadableAlert.classList.remove('d-none');
+        this.unreadableAlert.scrollIntoView({ behavior: 'smooth' });
+    }
+
     displayOverallScore(score) {
         const scoreElement = document.getElementById('overallScore');
         const descriptionElement = document.getElementById('scoreDescription');
@@ -319,10 +432,23 @@ class ATSAnalyzer {
             const issuesList = document.createElement('ul');
             issuesList.className = 'list-unstyled mb-0';
             
+            const severityStyles = {
+                error: { className: 'text-danger', icon: 'fa-times-circle' },
+                warning: { className: 'text-warning', icon: 'fa-exclamation-triangle' },
+                info: { className: 'text-muted', icon: 'fa-info-circle' }
+            };
             formatScore.issues.forEach(issue => {
+                const style = severityStyles[issue.severity] || severityStyles.warning;
                 const listItem = document.createElement('li');
-                listItem.className = 'text-danger small mb-1';
-                listItem.innerHTML = `<i class="fas fa-exclamation-triangle me-1"></i>${issue}`;
+                listItem.className = `${style.className} small mb-1`;
+                listItem.innerHTML = `<i class="fas ${style.icon} me-1"></i>`;
+                listItem.appendChild(document.createTextNode(issue.message));
+                if (issue.location) {
+                    const location = document.createElement('span');
+                    location.className = 'text-muted ms-1';
+                    location.textContent = `(${issue.location})`;
+                    listItem.appendChild(location);
+                }
                 issuesList.appendChild(listItem);
             });
             
@@ -423,6 +549,8 @@ class ATSAnalyzer {
     }
 
     showLoading() {
+        document.getElementById('loadingMessage').textContent = 'Analyzing your resume...';
+        document.getElementById('analysisProgress').style.width = '0%';
         this.loadingState.classList.remove('d-none');
         this.analyzeBtn.disabled = true;
         this.analyzeBtn.innerHTML = '<i class="fas fa-spinner fa-spin me-2"></i>Analyzing...';
@@ -446,6 +574,7 @@ class ATSAnalyzer {
 
     hideResults() {
         this.resultsSection.classList.add('d-none');
+        this.unreadableAlert.classList.add('d-none');
     }
 }
 
@@ -7,20 +7,6 @@ import (
 	"strings"
 )
 
-// IsValidResumeFile checks if the uploaded file is a valid resume format
-func IsValidResumeFile(filename string) bool {
-	ext := strings.ToLower(filepath.Ext(filename))
-	validExtensions := []string{".pdf", ".docx"}
-	
-	for _, validExt := range validExtensions {
-		if ext == validExt {
-			return true
-		}
-	}
-	
-	return false
-}
-
 // ValidateFileSize checks if file size is within acceptable limits
 func ValidateFileSize(fileSize int64) error {
 	const maxSize = 10 * 1024 * 1024 // 10MB
@@ -146,9 +132,10 @@ func ValidatePhone(phone string) error {
 		}
 	}
 	
-	// Check length (US phone numbers)
-	if len(cleanPhone) < 10 || len(cleanPhone) > 15 {
-		return fmt.Errorf("phone number length invalid (should be 10-15 digits)")
+	// Check length (national numbers can be as short as 7 digits and E.164
+	// numbers are at most 15)
+	if len(cleanPhone) < 7 || len(cleanPhone) > 15 {
+		return fmt.Errorf("phone number length invalid (should be 7-15 digits)")
 	}
 	
 	return nil
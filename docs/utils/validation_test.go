@@ -0,0 +1,30 @@
+package utils
+
+import "testing"
+
+func TestValidatePhone(t *testing.T) {
+	tests := []struct {
+		phone string
+		valid bool
+	}{
+		{"+44 20 7946 0958", true},
+		{"(555) 123-4567", true},
+		{"415.555.0132", true},
+		{"5550123", true},
+		{"+123456789012345", true},
+		{"555012", false},
+		{"+1234567890123456", false},
+		{"", false},
+		{"555-CALL-NOW", false},
+		{"ext. 1234567", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.phone, func(t *testing.T) {
+			err := ValidatePhone(tt.phone)
+			if (err == nil) != tt.valid {
+				t.Errorf("ValidatePhone(%q) = %v, want valid %v", tt.phone, err, tt.valid)
+			}
+		})
+	}
+}